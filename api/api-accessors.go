@@ -104,6 +104,33 @@ func (a *Attribute) GetTrafficTypeID() string {
 	return *a.TrafficTypeID
 }
 
+// GetNextMarker returns the NextMarker field if it's non-nil, zero value otherwise.
+func (a *AttributeListResult) GetNextMarker() string {
+	if a == nil || a.NextMarker == nil {
+		return ""
+	}
+	return *a.NextMarker
+}
+
+// HasObjects checks if AttributeListResult has any Objects.
+func (a *AttributeListResult) HasObjects() bool {
+	if a == nil || a.Objects == nil {
+		return false
+	}
+	if len(a.Objects) == 0 {
+		return false
+	}
+	return true
+}
+
+// GetPreviousMarker returns the PreviousMarker field if it's non-nil, zero value otherwise.
+func (a *AttributeListResult) GetPreviousMarker() string {
+	if a == nil || a.PreviousMarker == nil {
+		return ""
+	}
+	return *a.PreviousMarker
+}
+
 // GetDataType returns the DataType field if it's non-nil, zero value otherwise.
 func (a *AttributeRequest) GetDataType() string {
 	if a == nil || a.DataType == nil {
@@ -163,6 +190,22 @@ func (a *AttributeRequest) GetTrafficTypeID() string {
 	return *a.TrafficTypeID
 }
 
+// GetFrom returns the From field if it's non-nil, zero value otherwise.
+func (b *Between) GetFrom() int {
+	if b == nil || b.From == nil {
+		return 0
+	}
+	return *b.From
+}
+
+// GetTo returns the To field if it's non-nil, zero value otherwise.
+func (b *Between) GetTo() int {
+	if b == nil || b.To == nil {
+		return 0
+	}
+	return *b.To
+}
+
 // GetSize returns the Size field if it's non-nil, zero value otherwise.
 func (b *Bucket) GetSize() int {
 	if b == nil || b.Size == nil {
@@ -179,6 +222,238 @@ func (b *Bucket) GetTreatment() string {
 	return *b.Treatment
 }
 
+// HasApprovers checks if ChangeRequest has any Approvers.
+func (c *ChangeRequest) HasApprovers() bool {
+	if c == nil || c.Approvers == nil {
+		return false
+	}
+	if len(c.Approvers) == 0 {
+		return false
+	}
+	return true
+}
+
+// GetComment returns the Comment field if it's non-nil, zero value otherwise.
+func (c *ChangeRequest) GetComment() string {
+	if c == nil || c.Comment == nil {
+		return ""
+	}
+	return *c.Comment
+}
+
+// HasComments checks if ChangeRequest has any Comments.
+func (c *ChangeRequest) HasComments() bool {
+	if c == nil || c.Comments == nil {
+		return false
+	}
+	if len(c.Comments) == 0 {
+		return false
+	}
+	return true
+}
+
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (c *ChangeRequest) GetID() string {
+	if c == nil || c.ID == nil {
+		return ""
+	}
+	return *c.ID
+}
+
+// GetOperationType returns the OperationType field if it's non-nil, zero value otherwise.
+func (c *ChangeRequest) GetOperationType() string {
+	if c == nil || c.OperationType == nil {
+		return ""
+	}
+	return *c.OperationType
+}
+
+// GetSegment returns the Segment field.
+func (c *ChangeRequest) GetSegment() *ChangeRequestSegment {
+	if c == nil {
+		return nil
+	}
+	return c.Segment
+}
+
+// GetSplit returns the Split field.
+func (c *ChangeRequest) GetSplit() *ChangeRequestSplit {
+	if c == nil {
+		return nil
+	}
+	return c.Split
+}
+
+// GetStatus returns the Status field if it's non-nil, zero value otherwise.
+func (c *ChangeRequest) GetStatus() string {
+	if c == nil || c.Status == nil {
+		return ""
+	}
+	return *c.Status
+}
+
+// GetTitle returns the Title field if it's non-nil, zero value otherwise.
+func (c *ChangeRequest) GetTitle() string {
+	if c == nil || c.Title == nil {
+		return ""
+	}
+	return *c.Title
+}
+
+// GetComment returns the Comment field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestComment) GetComment() string {
+	if c == nil || c.Comment == nil {
+		return ""
+	}
+	return *c.Comment
+}
+
+// GetRole returns the Role field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestComment) GetRole() string {
+	if c == nil || c.Role == nil {
+		return ""
+	}
+	return *c.Role
+}
+
+// GetTimestamp returns the Timestamp field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestComment) GetTimestamp() int64 {
+	if c == nil || c.Timestamp == nil {
+		return 0
+	}
+	return *c.Timestamp
+}
+
+// GetUser returns the User field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestComment) GetUser() string {
+	if c == nil || c.User == nil {
+		return ""
+	}
+	return *c.User
+}
+
+// GetCount returns the Count field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestListResult) GetCount() int {
+	if c == nil || c.Count == nil {
+		return 0
+	}
+	return *c.Count
+}
+
+// HasData checks if ChangeRequestListResult has any Data.
+func (c *ChangeRequestListResult) HasData() bool {
+	if c == nil || c.Data == nil {
+		return false
+	}
+	if len(c.Data) == 0 {
+		return false
+	}
+	return true
+}
+
+// GetLimit returns the Limit field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestListResult) GetLimit() int {
+	if c == nil || c.Limit == nil {
+		return 0
+	}
+	return *c.Limit
+}
+
+// GetNextMarker returns the NextMarker field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestListResult) GetNextMarker() string {
+	if c == nil || c.NextMarker == nil {
+		return ""
+	}
+	return *c.NextMarker
+}
+
+// GetPreviousMarker returns the PreviousMarker field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestListResult) GetPreviousMarker() string {
+	if c == nil || c.PreviousMarker == nil {
+		return ""
+	}
+	return *c.PreviousMarker
+}
+
+// HasApprovers checks if ChangeRequestRequest has any Approvers.
+func (c *ChangeRequestRequest) HasApprovers() bool {
+	if c == nil || c.Approvers == nil {
+		return false
+	}
+	if len(c.Approvers) == 0 {
+		return false
+	}
+	return true
+}
+
+// GetSegment returns the Segment field.
+func (c *ChangeRequestRequest) GetSegment() *ChangeRequestSegment {
+	if c == nil {
+		return nil
+	}
+	return c.Segment
+}
+
+// GetSplit returns the Split field.
+func (c *ChangeRequestRequest) GetSplit() *ChangeRequestSplit {
+	if c == nil {
+		return nil
+	}
+	return c.Split
+}
+
+// HasKeys checks if ChangeRequestSegment has any Keys.
+func (c *ChangeRequestSegment) HasKeys() bool {
+	if c == nil || c.Keys == nil {
+		return false
+	}
+	if len(c.Keys) == 0 {
+		return false
+	}
+	return true
+}
+
+// HasDefaultRule checks if ChangeRequestSplit has any DefaultRule.
+func (c *ChangeRequestSplit) HasDefaultRule() bool {
+	if c == nil || c.DefaultRule == nil {
+		return false
+	}
+	if len(c.DefaultRule) == 0 {
+		return false
+	}
+	return true
+}
+
+// HasRules checks if ChangeRequestSplit has any Rules.
+func (c *ChangeRequestSplit) HasRules() bool {
+	if c == nil || c.Rules == nil {
+		return false
+	}
+	if len(c.Rules) == 0 {
+		return false
+	}
+	return true
+}
+
+// GetTrafficAllocation returns the TrafficAllocation field if it's non-nil, zero value otherwise.
+func (c *ChangeRequestSplit) GetTrafficAllocation() int {
+	if c == nil || c.TrafficAllocation == nil {
+		return 0
+	}
+	return *c.TrafficAllocation
+}
+
+// HasTreatments checks if ChangeRequestSplit has any Treatments.
+func (c *ChangeRequestSplit) HasTreatments() bool {
+	if c == nil || c.Treatments == nil {
+		return false
+	}
+	if len(c.Treatments) == 0 {
+		return false
+	}
+	return true
+}
+
 // GetCombiner returns the Combiner field if it's non-nil, zero value otherwise.
 func (c *Condition) GetCombiner() string {
 	if c == nil || c.Combiner == nil {
@@ -198,6 +473,33 @@ func (c *Condition) HasMatchers() bool {
 	return true
 }
 
+// GetRetryPolicy returns the RetryPolicy field.
+func (c *Config) GetRetryPolicy() *RetryPolicy {
+	if c == nil {
+		return nil
+	}
+	return c.RetryPolicy
+}
+
+// GetSplitName returns the SplitName field if it's non-nil, zero value otherwise.
+func (d *Depends) GetSplitName() string {
+	if d == nil || d.SplitName == nil {
+		return ""
+	}
+	return *d.SplitName
+}
+
+// HasTreatments checks if Depends has any Treatments.
+func (d *Depends) HasTreatments() bool {
+	if d == nil || d.Treatments == nil {
+		return false
+	}
+	if len(d.Treatments) == 0 {
+		return false
+	}
+	return true
+}
+
 // HasApiTokens checks if Environment has any ApiTokens.
 func (e *Environment) HasApiTokens() bool {
 	if e == nil || e.ApiTokens == nil {
@@ -348,6 +650,14 @@ func (e *EnvironmentSegmentKeysRequest) HasKeys() bool {
 	return true
 }
 
+// GetSplitError returns the SplitError field.
+func (e *ErrorResponse) GetSplitError() *SplitError {
+	if e == nil {
+		return nil
+	}
+	return e.SplitError
+}
+
 // GetDescription returns the Description field if it's non-nil, zero value otherwise.
 func (f *FlagSet) GetDescription() string {
 	if f == nil || f.Description == nil {
@@ -380,6 +690,22 @@ func (f *FlagSet) GetWorkspace() *WorkspaceIDRef {
 	return f.Workspace
 }
 
+// GetID returns the ID field if it's non-nil, zero value otherwise.
+func (f *FlagSetIDRef) GetID() string {
+	if f == nil || f.ID == nil {
+		return ""
+	}
+	return *f.ID
+}
+
+// GetType returns the Type field if it's non-nil, zero value otherwise.
+func (f *FlagSetIDRef) GetType() string {
+	if f == nil || f.Type == nil {
+		return ""
+	}
+	return *f.Type
+}
+
 // GetNextMarker returns the NextMarker field if it's non-nil, zero value otherwise.
 func (f *FlagSetListResult) GetNextMarker() string {
 	if f == nil || f.NextMarker == nil {
@@ -619,6 +945,14 @@ func (m *Matcher) GetAttribute() string {
 	return *m.Attribute
 }
 
+// GetBetween returns the Between field.
+func (m *Matcher) GetBetween() *Between {
+	if m == nil {
+		return nil
+	}
+	return m.Between
+}
+
 // GetBool returns the Bool field if it's non-nil, zero value otherwise.
 func (m *Matcher) GetBool() bool {
 	if m == nil || m.Bool == nil {
@@ -635,6 +969,14 @@ func (m *Matcher) GetDate() int {
 	return *m.Date
 }
 
+// GetDepends returns the Depends field.
+func (m *Matcher) GetDepends() *Depends {
+	if m == nil {
+		return nil
+	}
+	return m.Depends
+}
+
 // GetNegate returns the Negate field if it's non-nil, zero value otherwise.
 func (m *Matcher) GetNegate() bool {
 	if m == nil || m.Negate == nil {
@@ -678,6 +1020,17 @@ func (m *Matcher) GetType() string {
 	return *m.Type
 }
 
+// HasRetryableStatusCodes checks if RetryPolicy has any RetryableStatusCodes.
+func (r *RetryPolicy) HasRetryableStatusCodes() bool {
+	if r == nil || r.RetryableStatusCodes == nil {
+		return false
+	}
+	if len(r.RetryableStatusCodes) == 0 {
+		return false
+	}
+	return true
+}
+
 // HasBuckets checks if Rule has any Buckets.
 func (r *Rule) HasBuckets() bool {
 	if r == nil || r.Buckets == nil {
@@ -794,6 +1147,17 @@ func (s *Split) GetDescription() string {
 	return *s.Description
 }
 
+// HasFlagSets checks if Split has any FlagSets.
+func (s *Split) HasFlagSets() bool {
+	if s == nil || s.FlagSets == nil {
+		return false
+	}
+	if len(s.FlagSets) == 0 {
+		return false
+	}
+	return true
+}
+
 // GetID returns the ID field if it's non-nil, zero value otherwise.
 func (s *Split) GetID() string {
 	if s == nil || s.ID == nil {
@@ -994,6 +1358,49 @@ func (s *SplitDefinitions) HasObjects() bool {
 	return true
 }
 
+// GetCode returns the Code field if it's non-nil, zero value otherwise.
+func (s *SplitError) GetCode() int {
+	if s == nil || s.Code == nil {
+		return 0
+	}
+	return *s.Code
+}
+
+// GetDetails returns the Details field if it's non-nil, zero value otherwise.
+func (s *SplitError) GetDetails() string {
+	if s == nil || s.Details == nil {
+		return ""
+	}
+	return *s.Details
+}
+
+// GetMessage returns the Message field if it's non-nil, zero value otherwise.
+func (s *SplitError) GetMessage() string {
+	if s == nil || s.Message == nil {
+		return ""
+	}
+	return *s.Message
+}
+
+// GetTransactionID returns the TransactionID field if it's non-nil, zero value otherwise.
+func (s *SplitError) GetTransactionID() string {
+	if s == nil || s.TransactionID == nil {
+		return ""
+	}
+	return *s.TransactionID
+}
+
+// HasIDs checks if SplitFlagSetsRequest has any IDs.
+func (s *SplitFlagSetsRequest) HasIDs() bool {
+	if s == nil || s.IDs == nil {
+		return false
+	}
+	if len(s.IDs) == 0 {
+		return false
+	}
+	return true
+}
+
 // GetID returns the ID field if it's non-nil, zero value otherwise.
 func (s *SplitRolloutStatus) GetID() string {
 	if s == nil || s.ID == nil {
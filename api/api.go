@@ -1,10 +1,11 @@
 package api
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"log"
-	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/davidji99/simpleresty"
@@ -14,6 +15,12 @@ const (
 	// DefaultAPIBaseURL is the base API url.
 	DefaultAPIBaseURL = "https://api.split.io/internal/api/v2"
 
+	// DefaultAPIv3BaseURL is the base API url for endpoints only available in the Split APIv3, such as flag sets.
+	DefaultAPIv3BaseURL = "https://api.split.io/api/v3"
+
+	// apiV2PathSuffix is the path suffix of the APIv2 base URL, used to derive the APIv3 base URL.
+	apiV2PathSuffix = "/internal/api/v2"
+
 	// DefaultUserAgent is the user agent used when making API calls.
 	DefaultUserAgent = "split-go"
 
@@ -23,8 +30,12 @@ const (
 	// DefaultAcceptHeader is the default and Content-Type header.
 	DefaultAcceptHeader = "application/json"
 
-	//DefaultClientTimeout is the default timeout before the client stops making api calls, will be overriden by split/config.go when instantiate
-	DefaultClientTimeout = 300 // 5 min
+	// DefaultClientTimeout is the default overall budget, in seconds, for all API calls made by the client.
+	// Zero means there is no overall budget and only per request and per operation deadlines apply.
+	DefaultClientTimeout = 0
+
+	// DefaultRequestTimeout is the default timeout of a single HTTP request attempt.
+	DefaultRequestTimeout = 2 * time.Minute
 
 	UserStatusPending     = "PENDING"
 	UserStatusActive      = "ACTIVE"
@@ -44,20 +55,24 @@ type Client struct {
 	// config represents all of the API's configurations.
 	config *Config
 
-	//expiresAt is a property that contains the time when the timeout happens
+	// expiresAt is when the overall client budget runs out. It is zero if there is no budget.
 	expiresAt time.Time
 
+	// budgetOnce starts the overall client budget on the first request.
+	budgetOnce sync.Once
+
 	// Services used for talking to different parts of the Sendgrid APIv3.
-	ApiKeys      *KeysService
-	Attributes   *AttributesService
-	Environments *EnvironmentsService
-	FlagSets     *FlagSetsService
-	Groups       *GroupsService
-	TrafficTypes *TrafficTypesService
-	Segments     *SegmentsService
-	Splits       *SplitsService
-	Users        *UsersService
-	Workspaces   *WorkspacesService
+	ApiKeys        *KeysService
+	Attributes     *AttributesService
+	ChangeRequests *ChangeRequestsService
+	Environments   *EnvironmentsService
+	FlagSets       *FlagSetsService
+	Groups         *GroupsService
+	TrafficTypes   *TrafficTypesService
+	Segments       *SegmentsService
+	Splits         *SplitsService
+	Users          *UsersService
+	Workspaces     *WorkspacesService
 }
 
 // service represents the API service client.
@@ -89,7 +104,9 @@ func New(opts ...Option) (*Client, error) {
 		ContentTypeHeader: DefaultContentTypeHeader,
 		AcceptHeader:      DefaultAcceptHeader,
 		ClientTimeout:     DefaultClientTimeout,
+		RequestTimeout:    DefaultRequestTimeout,
 		APIKey:            "",
+		RetryPolicy:       NewRetryPolicy(),
 	}
 
 	// Define any user custom Client settings
@@ -97,12 +114,17 @@ func New(opts ...Option) (*Client, error) {
 		return nil, optErr
 	}
 
-	expiresAt := time.Now().Add(time.Duration(config.ClientTimeout) * time.Second)
+	if config.APIv3BaseURL == "" {
+		apiV3BaseURL, deriveErr := DeriveAPIv3BaseURL(config.APIBaseURL)
+		if deriveErr != nil {
+			return nil, deriveErr
+		}
+		config.APIv3BaseURL = apiV3BaseURL
+	}
 
 	client := &Client{
-		config:    config,
-		http:      simpleresty.NewWithBaseURL(config.APIBaseURL),
-		expiresAt: expiresAt,
+		config: config,
+		http:   simpleresty.NewWithBaseURL(config.APIBaseURL),
 	}
 
 	// Set headers
@@ -119,6 +141,7 @@ func (c *Client) injectServices() {
 	c.common.client = c
 	c.ApiKeys = (*KeysService)(&c.common)
 	c.Attributes = (*AttributesService)(&c.common)
+	c.ChangeRequests = (*ChangeRequestsService)(&c.common)
 	c.Environments = (*EnvironmentsService)(&c.common)
 	c.FlagSets = (*FlagSetsService)(&c.common)
 	c.Groups = (*GroupsService)(&c.common)
@@ -133,7 +156,7 @@ func (c *Client) setHeaders() {
 	c.http.SetHeader("Content-type", c.config.ContentTypeHeader).
 		SetHeader("Accept", c.config.AcceptHeader).
 		SetHeader("User-Agent", c.config.UserAgent).
-		SetTimeout(2 * time.Minute).
+		SetTimeout(c.config.RequestTimeout).
 		SetAllowGetMethodPayload(true)
 
 	// Add authentication headers based on what's available
@@ -153,78 +176,74 @@ func (c *Client) setHeaders() {
 	}
 }
 
-func (c *Client) checkRateLimit(resp *simpleresty.Response) bool {
-	if resp != nil && resp.StatusCode == 429 {
-		remainingOrgSeconds, _ := strconv.Atoi((resp.Resp.Header().Get("X-RateLimit-Reset-Seconds-Org")))
-		timeToSleep, _ := strconv.Atoi(resp.Resp.Header().Get("X-RateLimit-Reset-Seconds-IP"))
-		if remainingOrgSeconds != 0 {
-			// Got rate-limit by Organization
-			timeToSleep = remainingOrgSeconds
-		}
-		// Got rate-limit by IP-addr
-		log.Printf("[DEBUG] Got rate-limited, sleeping for %d seconds", timeToSleep)
-		time.Sleep(time.Duration(timeToSleep) * time.Second)
-		return true
+// DeriveAPIv3BaseURL derives the APIv3 base URL from an APIv2 base URL by replacing the
+// "/internal/api/v2" path suffix with "/api/v3". An error is returned if the APIv2 base URL
+// does not have the expected suffix, in which case the APIv3 base URL must be set explicitly.
+func DeriveAPIv3BaseURL(apiBaseURL string) (string, error) {
+	if !strings.HasSuffix(apiBaseURL, apiV2PathSuffix) {
+		return "", fmt.Errorf("unable to derive the APIv3 base URL from %q as it does not end with %q, "+
+			"please set the APIv3 base URL explicitly", apiBaseURL, apiV2PathSuffix)
 	}
-	return false
+	return strings.TrimSuffix(apiBaseURL, apiV2PathSuffix) + "/api/v3", nil
+}
+
+// requestURLv3 constructs a request URL for the Split APIv3.
+func (c *Client) requestURLv3(template string, args ...interface{}) string {
+	return c.config.APIv3BaseURL + fmt.Sprintf(template, args...)
+}
+
+// startBudget starts the overall client budget, if one is configured, the first time it is called.
+func (c *Client) startBudget() {
+	c.budgetOnce.Do(func() {
+		if c.config.ClientTimeout > 0 {
+			c.expiresAt = time.Now().Add(time.Duration(c.config.ClientTimeout) * time.Second)
+		}
+	})
 }
 
 // checkTimeout returns true if timeout, false if still have time
 func (c *Client) checkTimeout() bool {
-	return time.Now().After(c.expiresAt)
+	c.startBudget()
+	return !c.expiresAt.IsZero() && time.Now().After(c.expiresAt)
 }
 
-func (c *Client) get(url string, r, body interface{}) (*simpleresty.Response, error) {
-	if !c.checkTimeout() {
-		response, getErr := c.http.Get(url, &r, body)
-		if c.checkRateLimit(response) {
-			response, getErr = c.get(url, &r, body)
-		}
-		return response, getErr
+// deadline returns the earliest of the overall client budget and the deadline of ctx.
+func (c *Client) deadline(ctx context.Context) (time.Time, bool) {
+	c.startBudget()
+	d, ok := ctx.Deadline()
+	if !c.expiresAt.IsZero() && (!ok || c.expiresAt.Before(d)) {
+		return c.expiresAt, true
 	}
-	return nil, errors.New(timeoutError)
+	return d, ok
 }
 
-func (c *Client) post(url string, r, opts interface{}) (*simpleresty.Response, error) {
-	if !c.checkTimeout() {
-		response, getErr := c.http.Post(url, &r, opts)
-		if c.checkRateLimit(response) {
-			response, getErr = c.post(url, &r, opts)
-		}
-		return response, getErr
-	}
-	return nil, errors.New(timeoutError)
+func (c *Client) get(ctx context.Context, url string, r, body interface{}) (*simpleresty.Response, error) {
+	return c.do(ctx, simpleresty.GetMethod, url, r, body)
 }
 
-func (c *Client) put(url string, r, opts interface{}) (*simpleresty.Response, error) {
-	if !c.checkTimeout() {
-		response, getErr := c.http.Put(url, &r, opts)
-		if c.checkRateLimit(response) {
-			response, getErr = c.put(url, &r, opts)
-		}
-		return response, getErr
-	}
-	return nil, errors.New(timeoutError)
+func (c *Client) post(ctx context.Context, url string, r, opts interface{}) (*simpleresty.Response, error) {
+	return c.do(ctx, simpleresty.PostMethod, url, r, opts)
 }
 
-func (c *Client) patch(url string, r, opts interface{}) (*simpleresty.Response, error) {
-	if !c.checkTimeout() {
-		response, getErr := c.http.Patch(url, &r, opts)
-		if c.checkRateLimit(response) {
-			response, getErr = c.patch(url, &r, opts)
-		}
-		return response, getErr
-	}
-	return nil, errors.New(timeoutError)
+func (c *Client) put(ctx context.Context, url string, r, opts interface{}) (*simpleresty.Response, error) {
+	return c.do(ctx, simpleresty.PutMethod, url, r, opts)
 }
 
-func (c *Client) delete(url string, r, opts interface{}) (*simpleresty.Response, error) {
-	if !c.checkTimeout() {
-		response, getErr := c.http.Delete(url, &r, opts)
-		if c.checkRateLimit(response) {
-			response, getErr = c.delete(url, &r, opts)
-		}
-		return response, getErr
-	}
-	return nil, errors.New(timeoutError)
+func (c *Client) patch(ctx context.Context, url string, r, opts interface{}) (*simpleresty.Response, error) {
+	return c.do(ctx, simpleresty.PatchMethod, url, r, opts)
+}
+
+func (c *Client) delete(ctx context.Context, url string, r, opts interface{}) (*simpleresty.Response, error) {
+	return c.do(ctx, simpleresty.DeleteMethod, url, r, opts)
+}
+
+// do executes a request bound to ctx, retrying it according to the client's retry policy.
+func (c *Client) do(ctx context.Context, method, url string, r, body interface{}) (*simpleresty.Response, error) {
+	return c.withRetry(ctx, method, func() (*simpleresty.Response, error) {
+		req := c.http.ConstructRequest(&r, body).SetContext(ctx)
+		req.Method = method
+		req.URL = url
+
+		return c.http.Dispatch(req)
+	})
 }
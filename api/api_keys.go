@@ -1,6 +1,10 @@
 package api
 
-import "github.com/davidji99/simpleresty"
+import (
+	"context"
+
+	"github.com/davidji99/simpleresty"
+)
 
 var (
 	ValidApiKeyTypes = []string{"client_side", "server_side", "admin"}
@@ -50,12 +54,12 @@ type KeyResponse struct {
 // Create an API key.
 //
 // Reference: https://docs.split.io/reference/create-an-api-key
-func (k *KeysService) Create(opts *KeyRequest) (*KeyResponse, *simpleresty.Response, error) {
+func (k *KeysService) Create(ctx context.Context, opts *KeyRequest) (*KeyResponse, *simpleresty.Response, error) {
 	var result KeyResponse
 	urlStr := k.client.http.RequestURL("/apiKeys")
 
 	// Execute the request
-	response, createErr := k.client.post(urlStr, &result, opts)
+	response, createErr := k.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -63,10 +67,10 @@ func (k *KeysService) Create(opts *KeyRequest) (*KeyResponse, *simpleresty.Respo
 // Delete an API key.
 //
 // Reference: https://docs.split.io/reference/delete-an-api-key
-func (k *KeysService) Delete(key string) (*simpleresty.Response, error) {
+func (k *KeysService) Delete(ctx context.Context, key string) (*simpleresty.Response, error) {
 	urlStr := k.client.http.RequestURL("/apiKeys/%s", key)
 	// Execute the request
-	response, err := k.client.delete(urlStr, nil, nil)
+	response, err := k.client.delete(ctx, urlStr, nil, nil)
 
 	return response, err
 }
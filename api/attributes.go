@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"github.com/davidji99/simpleresty"
 	"net/url"
@@ -35,10 +36,17 @@ type AttributeRequest struct {
 	SuggestedValues []string `json:"suggestedValues,omitempty"`
 }
 
+// AttributeListResult represents the response when listing attributes with pagination.
+type AttributeListResult struct {
+	Objects        []*Attribute `json:"objects"`
+	NextMarker     *string      `json:"nextMarker,omitempty"`
+	PreviousMarker *string      `json:"previousMarker,omitempty"`
+}
+
 // AttributeListQueryParams represents all query parameters available when listing attributes
 type AttributeListQueryParams struct {
 	// Whether to paginate the response.
-	Paginate bool `url:"Paginate,omitempty"`
+	Paginate bool `url:"paginate,omitempty"`
 
 	// Search prefix under which to look for attributes (ex. att returns attribute1, but not myAttribute).
 	// Search is case insensitive, and only available with pagination.
@@ -60,7 +68,7 @@ type AttributeListQueryParams struct {
 // List all attributes for a traffic type.
 //
 // Reference: https://docs.split.io/reference/get-attributes
-func (a *AttributesService) List(workspaceID, trafficTypeID string, opts *AttributeListQueryParams) ([]*Attribute, *simpleresty.Response, error) {
+func (a *AttributesService) List(ctx context.Context, workspaceID, trafficTypeID string, opts *AttributeListQueryParams) ([]*Attribute, *simpleresty.Response, error) {
 	var result []*Attribute
 	urlStr, urlStrErr := a.client.http.RequestURLWithQueryParams(fmt.Sprintf("/schema/ws/%s/trafficTypes/%s", workspaceID,
 		trafficTypeID), opts)
@@ -68,16 +76,44 @@ func (a *AttributesService) List(workspaceID, trafficTypeID string, opts *Attrib
 		return nil, nil, urlStrErr
 	}
 
-	response, listErr := a.client.get(urlStr, &result, nil)
+	response, listErr := a.client.get(ctx, urlStr, &result, nil)
 
 	return result, response, listErr
 }
 
+// ListAll retrieves every attribute for a traffic type, following the pagination markers.
+func (a *AttributesService) ListAll(ctx context.Context, workspaceID, trafficTypeID string, opts *AttributeListQueryParams) ([]*Attribute, *simpleresty.Response, error) {
+	params := AttributeListQueryParams{}
+	if opts != nil {
+		params = *opts
+	}
+	params.Paginate = true
+	if params.MarkerLimit == 0 {
+		params.MarkerLimit = DefaultMarkerPageSize
+	}
+
+	return ListAllMarker(ctx, func(ctx context.Context, marker string) ([]*Attribute, *string, *simpleresty.Response, error) {
+		var result AttributeListResult
+		params.AfterMarker = marker
+		urlStr, urlStrErr := a.client.http.RequestURLWithQueryParams(fmt.Sprintf("/schema/ws/%s/trafficTypes/%s", workspaceID,
+			trafficTypeID), params)
+		if urlStrErr != nil {
+			return nil, nil, nil, urlStrErr
+		}
+
+		response, listErr := a.client.get(ctx, urlStr, &result, nil)
+		if listErr != nil {
+			return nil, nil, response, listErr
+		}
+		return result.Objects, result.NextMarker, response, nil
+	})
+}
+
 // FindByID retrieves an attribute by its ID.
 //
 // This is a helper method as it is not possible to retrieve a single attribute.
-func (a *AttributesService) FindByID(workspaceID, trafficTypeID, attributeID string, opts *AttributeListQueryParams) (*Attribute, *simpleresty.Response, error) {
-	attributes, listResponse, listErr := a.List(workspaceID, trafficTypeID, opts)
+func (a *AttributesService) FindByID(ctx context.Context, workspaceID, trafficTypeID, attributeID string, opts *AttributeListQueryParams) (*Attribute, *simpleresty.Response, error) {
+	attributes, listResponse, listErr := a.ListAll(ctx, workspaceID, trafficTypeID, opts)
 	if listErr != nil {
 		return nil, listResponse, listErr
 	}
@@ -88,18 +124,18 @@ func (a *AttributesService) FindByID(workspaceID, trafficTypeID, attributeID str
 		}
 	}
 
-	return nil, nil, fmt.Errorf("could not find attribute [%s]", attributeID)
+	return nil, nil, newNotFoundError("attribute", attributeID)
 }
 
 // Create an attribute.
 //
 // Reference: https://docs.split.io/reference/save-attribute
-func (a *AttributesService) Create(workspaceID, trafficTypeID string, opts *AttributeRequest) (*Attribute, *simpleresty.Response, error) {
+func (a *AttributesService) Create(ctx context.Context, workspaceID, trafficTypeID string, opts *AttributeRequest) (*Attribute, *simpleresty.Response, error) {
 	var result Attribute
 	urlStr := a.client.http.RequestURL("/schema/ws/%s/trafficTypes/%s", workspaceID, trafficTypeID)
 
 	// Execute the request
-	response, createErr := a.client.post(urlStr, &result, opts)
+	response, createErr := a.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -107,13 +143,13 @@ func (a *AttributesService) Create(workspaceID, trafficTypeID string, opts *Attr
 // Update an attribute.
 //
 // Reference: https://docs.split.io/reference/update-attribute
-func (a *AttributesService) Update(workspaceID, trafficTypeID, attributeID string, opts *AttributeRequest) (*Attribute, *simpleresty.Response, error) {
+func (a *AttributesService) Update(ctx context.Context, workspaceID, trafficTypeID, attributeID string, opts *AttributeRequest) (*Attribute, *simpleresty.Response, error) {
 	var result Attribute
 	attributeIdEncoded := url.QueryEscape(attributeID)
 	urlStr := a.client.http.RequestURL("/schema/ws/%s/trafficTypes/%s/%s", workspaceID, trafficTypeID, attributeIdEncoded)
 
 	// Execute the request
-	response, createErr := a.client.patch(urlStr, &result, opts)
+	response, createErr := a.client.patch(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -121,12 +157,12 @@ func (a *AttributesService) Update(workspaceID, trafficTypeID, attributeID strin
 // Delete an attribute.
 //
 // Reference: https://docs.split.io/reference/delete-attribute
-func (a *AttributesService) Delete(workspaceID, trafficTypeID, attributeID string) (*simpleresty.Response, error) {
+func (a *AttributesService) Delete(ctx context.Context, workspaceID, trafficTypeID, attributeID string) (*simpleresty.Response, error) {
 	attributeIdEncoded := url.QueryEscape(attributeID)
 	urlStr := a.client.http.RequestURL("/schema/ws/%s/trafficTypes/%s/%s", workspaceID, trafficTypeID, attributeIdEncoded)
 
 	// Execute the request
-	response, deleteErr := a.client.delete(urlStr, nil, nil)
+	response, deleteErr := a.client.delete(ctx, urlStr, nil, nil)
 
 	return response, deleteErr
 }
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+
+	"github.com/davidji99/simpleresty"
+)
+
+// ChangeRequestsService handles communication with the change request related
+// methods of the Split.io APIv2.
+//
+// Change requests are required to modify split definitions and segments in environments
+// protected by approval flows.
+//
+// Reference: https://docs.split.io/reference/change-request-overview
+type ChangeRequestsService service
+
+const (
+	ChangeRequestStatusRequested = "REQUESTED"
+	ChangeRequestStatusApproved  = "APPROVED"
+	ChangeRequestStatusRejected  = "REJECTED"
+	ChangeRequestStatusWithdrawn = "WITHDRAWN"
+	ChangeRequestStatusPublished = "PUBLISHED"
+
+	ChangeRequestOperationCreate  = "CREATE"
+	ChangeRequestOperationUpdate  = "UPDATE"
+	ChangeRequestOperationKill    = "KILL"
+	ChangeRequestOperationRestore = "RESTORE"
+	ChangeRequestOperationArchive = "ARCHIVE"
+)
+
+// ChangeRequest is a proposed change to a split definition or segment awaiting approval.
+type ChangeRequest struct {
+	ID            *string                 `json:"id"`
+	Status        *string                 `json:"status"`
+	Title         *string                 `json:"title"`
+	Comment       *string                 `json:"comment"`
+	OperationType *string                 `json:"operationType"`
+	Split         *ChangeRequestSplit     `json:"split,omitempty"`
+	Segment       *ChangeRequestSegment   `json:"segment,omitempty"`
+	Approvers     []string                `json:"approvers"`
+	Comments      []*ChangeRequestComment `json:"comments"`
+}
+
+// ChangeRequestSplit is the split definition proposed by a change request.
+type ChangeRequestSplit struct {
+	Name              string      `json:"name"`
+	Treatments        []Treatment `json:"treatments,omitempty"`
+	Rules             []Rule      `json:"rules,omitempty"`
+	DefaultRule       []Bucket    `json:"defaultRule,omitempty"`
+	DefaultTreatment  string      `json:"defaultTreatment,omitempty"`
+	TrafficAllocation *int        `json:"trafficAllocation,omitempty"`
+}
+
+// ChangeRequestSegment is the set of segment keys proposed by a change request.
+type ChangeRequestSegment struct {
+	Name string   `json:"name"`
+	Keys []string `json:"keys"`
+}
+
+// ChangeRequestComment is a comment left on a change request by its submitter or an approver.
+type ChangeRequestComment struct {
+	Comment   *string `json:"comment"`
+	User      *string `json:"user"`
+	Role      *string `json:"role"`
+	Timestamp *int64  `json:"timestamp"`
+}
+
+// ChangeRequestRequest submits a change request.
+type ChangeRequestRequest struct {
+	Split         *ChangeRequestSplit   `json:"split,omitempty"`
+	Segment       *ChangeRequestSegment `json:"segment,omitempty"`
+	OperationType string                `json:"operationType"`
+	Title         string                `json:"title,omitempty"`
+	Comment       string                `json:"comment,omitempty"`
+	Approvers     []string              `json:"approvers,omitempty"`
+}
+
+// ChangeRequestStatusRequest approves, rejects or withdraws a change request.
+type ChangeRequestStatusRequest struct {
+	Status  string `json:"status"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ChangeRequestListResult represents a page of change requests.
+type ChangeRequestListResult struct {
+	Data           []*ChangeRequest `json:"data"`
+	NextMarker     *string          `json:"nextMarker,omitempty"`
+	PreviousMarker *string          `json:"previousMarker,omitempty"`
+	Limit          *int             `json:"limit"`
+	Count          *int             `json:"count"`
+}
+
+// ChangeRequestListOpts represents all query parameters when fetching change requests.
+type ChangeRequestListOpts struct {
+	// REQUESTED | APPROVED | REJECTED | WITHDRAWN | PUBLISHED are the allowed status values to filter by
+	Status string `url:"status,omitempty"`
+
+	// Only returns change requests of the environment
+	EnvironmentID string `url:"environmentId,omitempty"`
+
+	// 1-200 are the potential values. Default=50
+	Limit int `url:"limit,omitempty"`
+
+	// value of "previousMarker" in response
+	Before string `url:"before,omitempty"`
+
+	// value of "nextMarker" in response
+	After string `url:"after,omitempty"`
+}
+
+// List change requests.
+//
+// Reference: https://docs.split.io/reference/list-change-requests
+func (c *ChangeRequestsService) List(ctx context.Context, opts *ChangeRequestListOpts) (*ChangeRequestListResult, *simpleresty.Response, error) {
+	var result ChangeRequestListResult
+	urlStr, urlStrErr := c.client.http.RequestURLWithQueryParams("/changeRequests", opts)
+	if urlStrErr != nil {
+		return nil, nil, urlStrErr
+	}
+
+	// Execute the request
+	response, getErr := c.client.get(ctx, urlStr, &result, nil)
+
+	return &result, response, getErr
+}
+
+// ListAll retrieves every change request matching opts, following the pagination markers.
+func (c *ChangeRequestsService) ListAll(ctx context.Context, opts *ChangeRequestListOpts) ([]*ChangeRequest, *simpleresty.Response, error) {
+	params := ChangeRequestListOpts{}
+	if opts != nil {
+		params = *opts
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultMarkerPageSize
+	}
+
+	return ListAllMarker(ctx, func(ctx context.Context, marker string) ([]*ChangeRequest, *string, *simpleresty.Response, error) {
+		params.After = marker
+		result, response, err := c.List(ctx, &params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		return result.Data, result.NextMarker, response, nil
+	})
+}
+
+// Get a change request by its ID.
+func (c *ChangeRequestsService) Get(ctx context.Context, id string) (*ChangeRequest, *simpleresty.Response, error) {
+	var result ChangeRequest
+	urlStr := c.client.http.RequestURL("/changeRequests/%s", id)
+
+	// Execute the request
+	response, getErr := c.client.get(ctx, urlStr, &result, nil)
+
+	return &result, response, getErr
+}
+
+// Create a change request for a split definition or segment in an environment.
+//
+// Reference: https://docs.split.io/reference/create-change-request
+func (c *ChangeRequestsService) Create(ctx context.Context, workspaceID, environmentID string, opts *ChangeRequestRequest) (*ChangeRequest, *simpleresty.Response, error) {
+	var result ChangeRequest
+	urlStr := c.client.http.RequestURL("/changeRequests/ws/%s/environments/%s", workspaceID, environmentID)
+
+	// Execute the request
+	response, createErr := c.client.post(ctx, urlStr, &result, opts)
+
+	return &result, response, createErr
+}
+
+// UpdateStatus of a change request, for example to reject it.
+//
+// Reference: https://docs.split.io/reference/update-change-request-status
+func (c *ChangeRequestsService) UpdateStatus(ctx context.Context, id string, opts *ChangeRequestStatusRequest) (*ChangeRequest, *simpleresty.Response, error) {
+	var result ChangeRequest
+	urlStr := c.client.http.RequestURL("/changeRequests/%s", id)
+
+	// Execute the request
+	response, updateErr := c.client.put(ctx, urlStr, &result, opts)
+
+	return &result, response, updateErr
+}
+
+// Approve a change request. The approver must be one of the change request's approvers.
+func (c *ChangeRequestsService) Approve(ctx context.Context, id, comment string) (*ChangeRequest, *simpleresty.Response, error) {
+	return c.UpdateStatus(ctx, id, &ChangeRequestStatusRequest{Status: ChangeRequestStatusApproved, Comment: comment})
+}
+
+// Withdraw a change request. Only the submitter of the change request can withdraw it.
+func (c *ChangeRequestsService) Withdraw(ctx context.Context, id, comment string) (*ChangeRequest, *simpleresty.Response, error) {
+	return c.UpdateStatus(ctx, id, &ChangeRequestStatusRequest{Status: ChangeRequestStatusWithdrawn, Comment: comment})
+}
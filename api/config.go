@@ -1,10 +1,16 @@
 package api
 
+import "time"
+
 // Config represents all configuration options available to user to customize the API v2.
 type Config struct {
 	// APIBaseURL is the base URL for Sendgrid's API v3.
 	APIBaseURL string
 
+	// APIv3BaseURL is the base URL for endpoints only available in the Split APIv3.
+	// It is derived from APIBaseURL when not set.
+	APIv3BaseURL string
+
 	// UserAgent used when communicating with the Sendgrid API.
 	UserAgent string
 
@@ -23,8 +29,14 @@ type Config struct {
 	// HarnessToken is used for x-api-key header authentication
 	HarnessToken string
 
-	// ClientTimeout
+	// ClientTimeout is the overall budget, in seconds, for all API calls. Zero disables the budget.
 	ClientTimeout int
+
+	// RequestTimeout is the timeout of a single HTTP request attempt.
+	RequestTimeout time.Duration
+
+	// RetryPolicy determines how transient failures are retried.
+	RetryPolicy *RetryPolicy
 }
 
 // ParseOptions parses the supplied options functions.
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"time"
 )
 
 // Option is a functional option for configuring the API client.
@@ -19,6 +20,18 @@ func APIBaseURL(url string) Option {
 	}
 }
 
+// APIv3BaseURL allows for a custom API v3 base URL used by endpoints only available in the Split APIv3.
+func APIv3BaseURL(url string) Option {
+	return func(c *Config) error {
+		if err := validateBaseURLOption(url); err != nil {
+			return err
+		}
+
+		c.APIv3BaseURL = url
+		return nil
+	}
+}
+
 // UserAgent allows for a custom User Agent.
 func UserAgent(userAgent string) Option {
 	return func(c *Config) error {
@@ -43,7 +56,8 @@ func APIKey(token string) Option {
 	}
 }
 
-// ClientTimeout sets the client max timeout.
+// ClientTimeout sets the overall budget, in seconds, for all API calls made by the client.
+// The budget starts with the first request. Zero disables the budget.
 func ClientTimeout(duration int) Option {
 	return func(c *Config) error {
 		c.ClientTimeout = duration
@@ -51,6 +65,56 @@ func ClientTimeout(duration int) Option {
 	}
 }
 
+// RequestTimeout sets the timeout of a single HTTP request attempt.
+func RequestTimeout(d time.Duration) Option {
+	return func(c *Config) error {
+		if d <= 0 {
+			return fmt.Errorf("request timeout must be greater than zero")
+		}
+		c.RequestTimeout = d
+		return nil
+	}
+}
+
+// RetryMaxAttempts sets the maximum number of attempts made for a single request. A value of 1 disables retries.
+func RetryMaxAttempts(attempts int) Option {
+	return func(c *Config) error {
+		if attempts < 1 {
+			return fmt.Errorf("retry max attempts must be at least 1")
+		}
+		c.RetryPolicy.MaxAttempts = attempts
+		return nil
+	}
+}
+
+// RetryBackoff sets the base and maximum backoff between retries.
+func RetryBackoff(base, max time.Duration) Option {
+	return func(c *Config) error {
+		if base < 0 || max < base {
+			return fmt.Errorf("retry backoff must satisfy 0 <= base <= max")
+		}
+		c.RetryPolicy.BaseBackoff = base
+		c.RetryPolicy.MaxBackoff = max
+		return nil
+	}
+}
+
+// RetryJitter enables or disables randomizing the backoff between retries.
+func RetryJitter(enabled bool) Option {
+	return func(c *Config) error {
+		c.RetryPolicy.Jitter = enabled
+		return nil
+	}
+}
+
+// RetryableStatusCodes sets the response status codes that trigger a retry.
+func RetryableStatusCodes(codes ...int) Option {
+	return func(c *Config) error {
+		c.RetryPolicy.RetryableStatusCodes = codes
+		return nil
+	}
+}
+
 // validateBaseURLOption ensures that any custom base URLs do not end with a trailing slash.
 func validateBaseURLOption(url string) error {
 	// Validate that there is no trailing slashes before setting the custom baseURL
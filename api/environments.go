@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"github.com/davidji99/simpleresty"
 	"strconv"
@@ -61,10 +62,10 @@ type EnvironmentSegmentKeysRequest struct {
 // List all environments.
 //
 // Reference: https://docs.split.io/reference#get-environments
-func (e *EnvironmentsService) List(workspaceID string) ([]*Environment, *simpleresty.Response, error) {
+func (e *EnvironmentsService) List(ctx context.Context, workspaceID string) ([]*Environment, *simpleresty.Response, error) {
 	var result []*Environment
 	urlStr := e.client.http.RequestURL("/environments/ws/%s", workspaceID)
-	response, getErr := e.client.get(urlStr, &result, nil)
+	response, getErr := e.client.get(ctx, urlStr, &result, nil)
 
 	return result, response, getErr
 }
@@ -72,42 +73,82 @@ func (e *EnvironmentsService) List(workspaceID string) ([]*Environment, *simpler
 // ListSegments retrieves segments given an environment.
 //
 // Reference: https://docs.split.io/reference/list-segments-in-environment
-func (e *EnvironmentsService) ListSegments(workspaceID, environmentID string) (*SegmentListResult, *simpleresty.Response, error) {
+func (e *EnvironmentsService) ListSegments(ctx context.Context, workspaceID, environmentID string, opts ...interface{}) (*SegmentListResult, *simpleresty.Response, error) {
 	var result SegmentListResult
-	urlStr := e.client.http.RequestURL("/segments/ws/%s/environments/%s", workspaceID, environmentID)
-	response, getErr := e.client.get(urlStr, &result, nil)
+	urlStr, err := e.client.http.RequestURLWithQueryParams(fmt.Sprintf("/segments/ws/%s/environments/%s", workspaceID, environmentID), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, getErr := e.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
 
+// ListAllSegments retrieves every segment in an environment, following pagination.
+func (e *EnvironmentsService) ListAllSegments(ctx context.Context, workspaceID, environmentID string) ([]*Segment, *simpleresty.Response, error) {
+	return ListAllOffset(ctx, DefaultOffsetPageSize, func(ctx context.Context, params GenericListQueryParams) ([]*Segment, *GenericListResult, *simpleresty.Response, error) {
+		result, response, err := e.ListSegments(ctx, workspaceID, environmentID, params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		return result.Objects, &result.GenericListResult, response, nil
+	})
+}
+
 // AddSegmentKeys for a given an environment.
 //
 // Reference: https://docs.split.io/reference/update-segment-keys-in-environment-via-json
-func (e *EnvironmentsService) AddSegmentKeys(environmentID, segmentName string, shouldReplace bool, opts *EnvironmentSegmentKeysRequest) (*EnvironmentSegment, *simpleresty.Response, error) {
+func (e *EnvironmentsService) AddSegmentKeys(ctx context.Context, environmentID, segmentName string, shouldReplace bool, opts *EnvironmentSegmentKeysRequest) (*EnvironmentSegment, *simpleresty.Response, error) {
 	var result EnvironmentSegment
 	urlStr := e.client.http.RequestURL("/segments/%s/%s/uploadKeys?replace=%v", environmentID, segmentName, shouldReplace)
-	response, updateErr := e.client.put(urlStr, &result, opts)
+	response, updateErr := e.client.put(ctx, urlStr, &result, opts)
 
 	return &result, response, updateErr
 }
 
-// GetSegmentKeys retrieves segment keys given an environment.
+// GetSegmentKeys retrieves a page of segment keys given an environment.
 //
 // Reference: https://docs.split.io/reference/get-segment-keys-in-environment
-func (e *EnvironmentsService) GetSegmentKeys(environmentID, segmentName string) (*SegmentKeysList, *simpleresty.Response, error) {
+func (e *EnvironmentsService) GetSegmentKeys(ctx context.Context, environmentID, segmentName string, opts ...interface{}) (*SegmentKeysList, *simpleresty.Response, error) {
 	var result SegmentKeysList
-	urlStr := e.client.http.RequestURL("/segments/%s/%s/keys", environmentID, segmentName)
-	response, getErr := e.client.get(urlStr, &result, nil)
+	urlStr, err := e.client.http.RequestURLWithQueryParams(fmt.Sprintf("/segments/%s/%s/keys", environmentID, segmentName), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, getErr := e.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
 
+// ListAllSegmentKeys retrieves every segment key given an environment, following pagination.
+func (e *EnvironmentsService) ListAllSegmentKeys(ctx context.Context, environmentID, segmentName string) ([]string, *simpleresty.Response, error) {
+	segmentKeys, response, err := ListAllOffset(ctx, DefaultSegmentKeysPageSize, func(ctx context.Context, params GenericListQueryParams) ([]*SegmentKey, *GenericListResult, *simpleresty.Response, error) {
+		result, response, err := e.GetSegmentKeys(ctx, environmentID, segmentName, params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		return result.Keys, &result.GenericListResult, response, nil
+	})
+	if err != nil {
+		return nil, response, err
+	}
+
+	keys := make([]string, 0, len(segmentKeys))
+	for _, k := range segmentKeys {
+		keys = append(keys, k.GetKey())
+	}
+
+	return keys, response, nil
+}
+
 // RemoveSegmentKeys removes segment keys given an environment.
 //
 // Reference: https://docs.split.io/reference/remove-segment-keys-from-environment
-func (e *EnvironmentsService) RemoveSegmentKeys(environmentID, segmentName string, opts *EnvironmentSegmentKeysRequest) (*simpleresty.Response, error) {
+func (e *EnvironmentsService) RemoveSegmentKeys(ctx context.Context, environmentID, segmentName string, opts *EnvironmentSegmentKeysRequest) (*simpleresty.Response, error) {
 	urlStr := e.client.http.RequestURL("/segments/%s/%s/removeKeys", environmentID, segmentName)
-	response, err := e.client.put(urlStr, nil, opts)
+	response, err := e.client.put(ctx, urlStr, nil, opts)
 
 	return response, err
 }
@@ -116,8 +157,8 @@ func (e *EnvironmentsService) RemoveSegmentKeys(environmentID, segmentName strin
 //
 // Note: this method uses the List() method to first return all environments and then look for the target environment
 // by an ID. The Split APIv2 does not provide a GET#show endpoint for environments unfortunately.
-func (e *EnvironmentsService) FindByID(workspaceID, envID string) (*Environment, *simpleresty.Response, error) {
-	envs, listResponse, listErr := e.List(workspaceID)
+func (e *EnvironmentsService) FindByID(ctx context.Context, workspaceID, envID string) (*Environment, *simpleresty.Response, error) {
+	envs, listResponse, listErr := e.List(ctx, workspaceID)
 	if listErr != nil {
 		return nil, listResponse, listErr
 	}
@@ -128,15 +169,15 @@ func (e *EnvironmentsService) FindByID(workspaceID, envID string) (*Environment,
 		}
 	}
 
-	return nil, nil, fmt.Errorf("environment [%s] not found", envID)
+	return nil, nil, newNotFoundError("environment", envID)
 }
 
 // FindByName retrieves an environment by its name.
 //
 // Note: this method uses the List() method to first return all environments and then look for the target environment
 // by an name. The Split APIv2 does not provide a GET#show endpoint for environments unfortunately.
-func (e *EnvironmentsService) FindByName(workspaceID, envName string) (*Environment, *simpleresty.Response, error) {
-	envs, listResponse, listErr := e.List(workspaceID)
+func (e *EnvironmentsService) FindByName(ctx context.Context, workspaceID, envName string) (*Environment, *simpleresty.Response, error) {
+	envs, listResponse, listErr := e.List(ctx, workspaceID)
 	if listErr != nil {
 		return nil, listResponse, listErr
 	}
@@ -147,18 +188,18 @@ func (e *EnvironmentsService) FindByName(workspaceID, envName string) (*Environm
 		}
 	}
 
-	return nil, nil, fmt.Errorf("environment [%s] not found", envName)
+	return nil, nil, newNotFoundError("environment", envName)
 }
 
 // Create an environment.
 //
 // Reference: https://docs.split.io/reference#create-environment
-func (e *EnvironmentsService) Create(workspaceID string, opts *EnvironmentRequest) (*Environment, *simpleresty.Response, error) {
+func (e *EnvironmentsService) Create(ctx context.Context, workspaceID string, opts *EnvironmentRequest) (*Environment, *simpleresty.Response, error) {
 	var result Environment
 	urlStr := e.client.http.RequestURL("/environments/ws/%s", workspaceID)
 
 	// Execute the request
-	response, createErr := e.client.post(urlStr, &result, opts)
+	response, createErr := e.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -172,7 +213,7 @@ type environmentPatchRequest struct {
 // Update an environment.
 //
 // Reference: https://docs.split.io/reference#update-environment
-func (e *EnvironmentsService) Update(workspaceID, envID string, opts *EnvironmentRequest) (*Environment, *simpleresty.Response, error) {
+func (e *EnvironmentsService) Update(ctx context.Context, workspaceID, envID string, opts *EnvironmentRequest) (*Environment, *simpleresty.Response, error) {
 	var result Environment
 	urlStr := e.client.http.RequestURL("/environments/ws/%s/%s", workspaceID, envID)
 
@@ -196,7 +237,7 @@ func (e *EnvironmentsService) Update(workspaceID, envID string, opts *Environmen
 	}
 
 	// Execute the request
-	response, getErr := e.client.patch(urlStr, &result, reqBody)
+	response, getErr := e.client.patch(ctx, urlStr, &result, reqBody)
 
 	return &result, response, getErr
 }
@@ -208,10 +249,10 @@ func (e *EnvironmentsService) Update(workspaceID, envID string, opts *Environmen
 // If deletion request is successful, the response body returns a "true" string.
 //
 // Reference: https://docs.split.io/reference#delete-environment
-func (e *EnvironmentsService) Delete(workspaceID, envID string) (*simpleresty.Response, error) {
+func (e *EnvironmentsService) Delete(ctx context.Context, workspaceID, envID string) (*simpleresty.Response, error) {
 	urlStr := e.client.http.RequestURL("/environments/ws/%s/%s", workspaceID, envID)
 	// Execute the request
-	response, getErr := e.client.delete(urlStr, nil, nil)
+	response, getErr := e.client.delete(ctx, urlStr, nil, nil)
 
 	return response, getErr
 }
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/davidji99/simpleresty"
+)
+
+// requestIDHeaders are the response headers, in order of preference, that may carry
+// an identifier for the request. These are useful when reaching out to Split support.
+var requestIDHeaders = []string{"X-Request-Id", "X-Trace-Id", "X-Amzn-Trace-Id", "X-Transaction-Id"}
+
+// ErrorResponse reports an unsuccessful response from the Split API.
+type ErrorResponse struct {
+	// Response is the raw response returned by the HTTP client.
+	Response *simpleresty.Response
+
+	// Method is the HTTP method of the request.
+	Method string
+
+	// URL is the URL of the request.
+	URL string
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header contains the response headers.
+	Header http.Header
+
+	// RequestID is the request or trace identifier found in the response headers, if any.
+	RequestID string
+
+	// Body is the raw response body.
+	Body string
+
+	// SplitError is the decoded Split error payload. It is nil if the response body could not be decoded.
+	SplitError *SplitError
+}
+
+// SplitError represents the error payload returned by the Split API.
+type SplitError struct {
+	Code          *int    `json:"code,omitempty"`
+	Message       *string `json:"message,omitempty"`
+	Details       *string `json:"details,omitempty"`
+	TransactionID *string `json:"transactionId,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *ErrorResponse) Error() string {
+	msg := e.Body
+	if e.SplitError.GetMessage() != "" {
+		msg = e.SplitError.GetMessage()
+		if e.SplitError.GetDetails() != "" {
+			msg = fmt.Sprintf("%s (%s)", msg, e.SplitError.GetDetails())
+		}
+	}
+
+	errStr := fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, msg)
+	if e.RequestID != "" {
+		errStr = fmt.Sprintf("%s [request id: %s]", errStr, e.RequestID)
+	}
+
+	return errStr
+}
+
+// newErrorResponse constructs an ErrorResponse from an unsuccessful response.
+func newErrorResponse(resp *simpleresty.Response) *ErrorResponse {
+	e := &ErrorResponse{
+		Response:   resp,
+		Method:     resp.RequestMethod,
+		URL:        resp.RequestURL,
+		StatusCode: resp.StatusCode,
+		Body:       resp.Body,
+	}
+
+	if resp.Resp != nil {
+		e.Header = resp.Resp.Header()
+		for _, h := range requestIDHeaders {
+			if v := e.Header.Get(h); v != "" {
+				e.RequestID = v
+				break
+			}
+		}
+	}
+
+	var splitErr SplitError
+	if resp.Body != "" && json.Unmarshal([]byte(resp.Body), &splitErr) == nil {
+		e.SplitError = &splitErr
+		if e.RequestID == "" {
+			e.RequestID = splitErr.GetTransactionID()
+		}
+	}
+
+	return e
+}
+
+// checkResponse converts an error returned by the HTTP client into an *ErrorResponse whenever
+// the API returned an unsuccessful response. Errors that occur before a response is received,
+// such as connection failures, are returned as is.
+func checkResponse(resp *simpleresty.Response, err error) error {
+	if err == nil || resp == nil {
+		return err
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case 200, 201, 202, 204, 304:
+		return err
+	}
+
+	return newErrorResponse(resp)
+}
+
+// NotFoundError is returned by lookup helpers, such as FindByID or FindByName, that search
+// a list of resources and do not find a match.
+type NotFoundError struct {
+	// Resource is the kind of resource that was searched for.
+	Resource string
+
+	// Identifier is the ID or name that was searched for.
+	Identifier string
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s [%s] not found", e.Resource, e.Identifier)
+}
+
+// newNotFoundError constructs a NotFoundError.
+func newNotFoundError(resource, identifier string) *NotFoundError {
+	return &NotFoundError{Resource: resource, Identifier: identifier}
+}
+
+// StatusCode returns the HTTP status code of err if it is an *ErrorResponse, otherwise zero.
+func StatusCode(err error) int {
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound returns true if err is the result of a 404 response or a lookup helper not finding a match.
+func IsNotFound(err error) bool {
+	var notFoundErr *NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return true
+	}
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict returns true if err is the result of a 409 response.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsRateLimited returns true if err is the result of a 429 response.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
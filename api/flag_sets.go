@@ -1,8 +1,10 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"github.com/davidji99/simpleresty"
+	"net/url"
 )
 
 // FlagSetsService handles communication with the flag sets related
@@ -25,6 +27,12 @@ type WorkspaceIDRef struct {
 	ID   *string `json:"id"`
 }
 
+// FlagSetIDRef represents a minimal representation of a flag set, as returned with the splits it contains.
+type FlagSetIDRef struct {
+	Type *string `json:"type"`
+	ID   *string `json:"id"`
+}
+
 // FlagSetRequest represents a request to create a flag set.
 type FlagSetRequest struct {
 	Name        *string         `json:"name,omitempty"`
@@ -42,65 +50,50 @@ type FlagSetListResult struct {
 // Create a flag set.
 //
 // Reference: https://docs.split.io/reference/create-flag-set
-func (f *FlagSetsService) Create(opts *FlagSetRequest) (*FlagSet, *simpleresty.Response, error) {
+func (f *FlagSetsService) Create(ctx context.Context, opts *FlagSetRequest) (*FlagSet, *simpleresty.Response, error) {
 	var result FlagSet
-	urlStr := "https://api.split.io/api/v3/flag-sets"
-	response, createErr := f.client.post(urlStr, &result, opts)
+	urlStr := f.client.requestURLv3("/flag-sets")
+	response, createErr := f.client.post(ctx, urlStr, &result, opts)
 	return &result, response, createErr
 }
 
 // FindByID retrieves a flag set by its ID.
 //
 // Reference: https://docs.split.io/reference/get-flag-set-by-id
-func (f *FlagSetsService) FindByID(id string) (*FlagSet, *simpleresty.Response, error) {
+func (f *FlagSetsService) FindByID(ctx context.Context, id string) (*FlagSet, *simpleresty.Response, error) {
 	var result FlagSet
-	urlStr := fmt.Sprintf("https://api.split.io/api/v3/flag-sets/%s", id)
-	response, getErr := f.client.get(urlStr, &result, nil)
+	urlStr := f.client.requestURLv3("/flag-sets/%s", id)
+	response, getErr := f.client.get(ctx, urlStr, &result, nil)
 	return &result, response, getErr
 }
 
 // List all flag sets for a given workspace.
 //
 // Reference: https://docs.split.io/reference/list-flag-sets
-func (f *FlagSetsService) List(workspaceID string) ([]*FlagSet, *simpleresty.Response, error) {
-	var allFlagSets []*FlagSet
-	var lastResponse *simpleresty.Response
-	var after string
-
-	for {
+func (f *FlagSetsService) List(ctx context.Context, workspaceID string) ([]*FlagSet, *simpleresty.Response, error) {
+	return ListAllMarker(ctx, func(ctx context.Context, marker string) ([]*FlagSet, *string, *simpleresty.Response, error) {
 		var result FlagSetListResult
-		urlStr := fmt.Sprintf("https://api.split.io/api/v3/flag-sets?workspace_id=%s&limit=200", workspaceID)
+		urlStr := f.client.requestURLv3("/flag-sets?workspace_id=%s&limit=%d", workspaceID, DefaultMarkerPageSize)
 
-		if after != "" {
-			urlStr += fmt.Sprintf("&after=%s", after)
+		if marker != "" {
+			urlStr += fmt.Sprintf("&after=%s", url.QueryEscape(marker))
 		}
 
-		response, getErr := f.client.get(urlStr, &result, nil)
-		lastResponse = response
+		response, getErr := f.client.get(ctx, urlStr, &result, nil)
 		if getErr != nil {
-			return allFlagSets, response, getErr
+			return nil, nil, response, getErr
 		}
 
-		if result.Objects != nil {
-			allFlagSets = append(allFlagSets, result.Objects...)
-		}
-
-		if result.NextMarker == nil || *result.NextMarker == "" {
-			break
-		}
-
-		after = *result.NextMarker
-	}
-
-	return allFlagSets, lastResponse, nil
+		return result.Objects, result.NextMarker, response, nil
+	})
 }
 
 // FindByName retrieves a flag set by its name.
 //
 // Note: this method uses the List() method to first return all flag sets and then look for the target flag set
 // by name. The Split APIv3 does not provide a direct GET endpoint to find flag sets by name.
-func (f *FlagSetsService) FindByName(workspaceID, name string) (*FlagSet, *simpleresty.Response, error) {
-	flagSets, listResponse, listErr := f.List(workspaceID)
+func (f *FlagSetsService) FindByName(ctx context.Context, workspaceID, name string) (*FlagSet, *simpleresty.Response, error) {
+	flagSets, listResponse, listErr := f.List(ctx, workspaceID)
 	if listErr != nil {
 		return nil, listResponse, listErr
 	}
@@ -111,14 +104,14 @@ func (f *FlagSetsService) FindByName(workspaceID, name string) (*FlagSet, *simpl
 		}
 	}
 
-	return nil, nil, fmt.Errorf("flag set [%s] not found", name)
+	return nil, nil, newNotFoundError("flag set", name)
 }
 
 // Delete a flag set.
 //
 // Reference: https://docs.split.io/reference/delete-flag-set-by-id
-func (f *FlagSetsService) Delete(id string) (*simpleresty.Response, error) {
-	urlStr := fmt.Sprintf("https://api.split.io/api/v3/flag-sets/%s", id)
-	response, getErr := f.client.delete(urlStr, nil, nil)
+func (f *FlagSetsService) Delete(ctx context.Context, id string) (*simpleresty.Response, error) {
+	urlStr := f.client.requestURLv3("/flag-sets/%s", id)
+	response, getErr := f.client.delete(ctx, urlStr, nil, nil)
 	return response, getErr
 }
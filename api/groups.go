@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"github.com/davidji99/simpleresty"
 )
 
@@ -42,7 +43,7 @@ type GroupRequest struct {
 // List all active Groups in the organization
 //
 // Reference: https://docs.split.io/reference#list-groups
-func (g *GroupsService) List(opts *GroupListOpts) (*GroupListResult, *simpleresty.Response, error) {
+func (g *GroupsService) List(ctx context.Context, opts *GroupListOpts) (*GroupListResult, *simpleresty.Response, error) {
 	var result GroupListResult
 	//Here I have a problem with the RequestURLWithParams, TBD
 	urlStr, urlStrErr := g.client.http.RequestURLWithQueryParams("/groups", opts)
@@ -51,7 +52,7 @@ func (g *GroupsService) List(opts *GroupListOpts) (*GroupListResult, *simplerest
 	}
 
 	// Execute the request
-	response, getErr := g.client.get(urlStr, &result, nil)
+	response, getErr := g.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
@@ -59,11 +60,11 @@ func (g *GroupsService) List(opts *GroupListOpts) (*GroupListResult, *simplerest
 // Get a group by their group Id.
 //
 // Reference: https://docs.split.io/reference#get-group
-func (g *GroupsService) Get(id string) (*Group, *simpleresty.Response, error) {
+func (g *GroupsService) Get(ctx context.Context, id string) (*Group, *simpleresty.Response, error) {
 	var result Group
 	urlStr := g.client.http.RequestURL("/groups/%s", id)
 
-	response, getErr := g.client.get(urlStr, &result, nil)
+	response, getErr := g.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
@@ -71,12 +72,12 @@ func (g *GroupsService) Get(id string) (*Group, *simpleresty.Response, error) {
 // Create a new group in your organization.
 //
 // Reference: https://docs.split.io/reference#create-group
-func (g *GroupsService) Create(opts *GroupRequest) (*Group, *simpleresty.Response, error) {
+func (g *GroupsService) Create(ctx context.Context, opts *GroupRequest) (*Group, *simpleresty.Response, error) {
 	var result Group
 	urlStr := g.client.http.RequestURL("/groups")
 
 	// Execute the request
-	response, getErr := g.client.post(urlStr, &result, opts)
+	response, getErr := g.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, getErr
 }
@@ -84,12 +85,12 @@ func (g *GroupsService) Create(opts *GroupRequest) (*Group, *simpleresty.Respons
 // Update a group in your organization.
 //
 // Reference: https://docs.split.io/reference#update-group
-func (g *GroupsService) Update(id string, opts *GroupRequest) (*Group, *simpleresty.Response, error) {
+func (g *GroupsService) Update(ctx context.Context, id string, opts *GroupRequest) (*Group, *simpleresty.Response, error) {
 	var result Group
 	urlStr := g.client.http.RequestURL("/groups/%s", id)
 
 	// Execute the request
-	response, getErr := g.client.put(urlStr, &result, opts)
+	response, getErr := g.client.put(ctx, urlStr, &result, opts)
 
 	return &result, response, getErr
 }
@@ -97,11 +98,11 @@ func (g *GroupsService) Update(id string, opts *GroupRequest) (*Group, *simplere
 // Delete a group in your organization.
 //
 // Reference: https://docs.split.io/reference#delete-group
-func (g *GroupsService) Delete(id string) (*simpleresty.Response, error) {
+func (g *GroupsService) Delete(ctx context.Context, id string) (*simpleresty.Response, error) {
 	urlStr := g.client.http.RequestURL("/groups/%s", id)
 
 	// Execute the request
-	response, getErr := g.client.delete(urlStr, nil, nil)
+	response, getErr := g.client.delete(ctx, urlStr, nil, nil)
 
 	return response, getErr
 }
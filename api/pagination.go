@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+
+	"github.com/davidji99/simpleresty"
+)
+
+const (
+	// DefaultOffsetPageSize is the page size used when paginating offset-based list endpoints.
+	DefaultOffsetPageSize = 50
+
+	// DefaultMarkerPageSize is the page size used when paginating marker-based list endpoints.
+	DefaultMarkerPageSize = 200
+
+	// DefaultSegmentKeysPageSize is the page size used when paginating the keys of a segment in an environment.
+	DefaultSegmentKeysPageSize = 100
+
+	// MaxSegmentKeysPerRequest is the maximum number of keys that can be added or removed in a single request.
+	MaxSegmentKeysPerRequest = 10000
+)
+
+// OffsetPageFunc retrieves a single page of an offset-based list endpoint.
+type OffsetPageFunc[T any] func(ctx context.Context, params GenericListQueryParams) ([]T, *GenericListResult, *simpleresty.Response, error)
+
+// MarkerPageFunc retrieves a single page of a marker-based list endpoint, starting after the given marker.
+// An empty marker retrieves the first page. It returns the marker of the next page, if any.
+type MarkerPageFunc[T any] func(ctx context.Context, marker string) ([]T, *string, *simpleresty.Response, error)
+
+// ListAllOffset retrieves every item of an offset-based list endpoint by requesting pages of pageSize items
+// until the total count is reached or a partial page is returned.
+//
+// The response of the last request is returned.
+func ListAllOffset[T any](ctx context.Context, pageSize int, fetch OffsetPageFunc[T]) ([]T, *simpleresty.Response, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultOffsetPageSize
+	}
+
+	all := make([]T, 0)
+	offset := 0
+
+	for {
+		items, page, response, err := fetch(ctx, GenericListQueryParams{Offset: offset, Limit: pageSize})
+		if err != nil {
+			return all, response, err
+		}
+
+		all = append(all, items...)
+		offset += len(items)
+
+		limit := page.GetLimit()
+		if limit <= 0 {
+			limit = pageSize
+		}
+
+		if len(items) == 0 || len(items) < limit {
+			return all, response, nil
+		}
+
+		if page.GetTotalCount() > 0 && offset >= page.GetTotalCount() {
+			return all, response, nil
+		}
+	}
+}
+
+// ListAllMarker retrieves every item of a marker-based list endpoint by following the next marker
+// until there are no more pages.
+//
+// The response of the last request is returned.
+func ListAllMarker[T any](ctx context.Context, fetch MarkerPageFunc[T]) ([]T, *simpleresty.Response, error) {
+	all := make([]T, 0)
+	marker := ""
+
+	for {
+		items, next, response, err := fetch(ctx, marker)
+		if err != nil {
+			return all, response, err
+		}
+
+		all = append(all, items...)
+
+		// Stop when there is no next page or the API hands back the same marker.
+		if next == nil || *next == "" || *next == marker {
+			return all, response, nil
+		}
+
+		marker = *next
+	}
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/davidji99/simpleresty"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default maximum number of attempts made for a single request.
+	DefaultRetryMaxAttempts = 5
+
+	// DefaultRetryBaseBackoff is the default backoff before the first retry.
+	DefaultRetryBaseBackoff = 1 * time.Second
+
+	// DefaultRetryMaxBackoff is the default upper bound of the backoff between retries.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// DefaultRetryableStatusCodes are the response status codes retried by default.
+var DefaultRetryableStatusCodes = []int{429, 502, 503, 504}
+
+// RetryPolicy configures how the client retries requests that failed due to transient errors,
+// such as rate limiting, gateway errors, connection resets and timeouts.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one. A value of 1 disables retries.
+	MaxAttempts int
+
+	// BaseBackoff is the backoff before the first retry. It doubles for every subsequent retry.
+	BaseBackoff time.Duration
+
+	// MaxBackoff is the upper bound of the backoff between retries.
+	MaxBackoff time.Duration
+
+	// Jitter randomizes each backoff between zero and its computed value.
+	Jitter bool
+
+	// RetryableStatusCodes are the response status codes that trigger a retry.
+	// Requests that are not idempotent are only retried on 429.
+	RetryableStatusCodes []int
+}
+
+// NewRetryPolicy returns a RetryPolicy with default values.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          DefaultRetryMaxAttempts,
+		BaseBackoff:          DefaultRetryBaseBackoff,
+		MaxBackoff:           DefaultRetryMaxBackoff,
+		Jitter:               true,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+// isRetryableStatusCode returns true if the status code is configured to be retried.
+func (p *RetryPolicy) isRetryableStatusCode(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns how long to wait before the given retry. The first retry is attempt 1.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := time.Duration(float64(p.BaseBackoff) * math.Pow(2, float64(attempt-1)))
+	if d > p.MaxBackoff || d <= 0 {
+		d = p.MaxBackoff
+	}
+
+	if p.Jitter && d > 0 {
+		d = time.Duration(rand.Int63n(int64(d) + 1))
+	}
+
+	return d
+}
+
+// isIdempotentMethod returns true if repeating a request with the given method has the same effect as sending it once.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry determines whether a request should be retried and how long to wait beforehand.
+//
+// Idempotent requests are retried on every retryable status code and transient network error. Other requests,
+// such as POST and PATCH, may have been applied even though they failed, so they are only retried when rate
+// limited or when the connection was refused before anything was sent.
+func (p *RetryPolicy) shouldRetry(method string, attempt int, resp *simpleresty.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	idempotent := isIdempotentMethod(method)
+
+	if resp == nil {
+		if (idempotent && isTransientNetworkError(err)) || errors.Is(err, syscall.ECONNREFUSED) {
+			return p.backoff(attempt), true
+		}
+		return 0, false
+	}
+
+	if !p.isRetryableStatusCode(resp.StatusCode) {
+		return 0, false
+	}
+
+	if !idempotent && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if wait, ok := rateLimitReset(resp); ok {
+		return min(wait, p.MaxBackoff), true
+	}
+
+	return p.backoff(attempt), true
+}
+
+// rateLimitReset returns the time to wait until the rate limit resets based on the response headers.
+//
+// Split rate limits by organization and by IP address. The organization reset takes precedence.
+func rateLimitReset(resp *simpleresty.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Resp == nil {
+		return 0, false
+	}
+
+	remainingOrgSeconds, _ := strconv.Atoi(resp.Resp.Header().Get("X-RateLimit-Reset-Seconds-Org"))
+	timeToSleep, _ := strconv.Atoi(resp.Resp.Header().Get("X-RateLimit-Reset-Seconds-IP"))
+	if remainingOrgSeconds != 0 {
+		// Got rate-limit by Organization
+		timeToSleep = remainingOrgSeconds
+	}
+
+	if timeToSleep <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(timeToSleep) * time.Second, true
+}
+
+// isTransientNetworkError returns true if err is a connection reset, an unexpected EOF or a timeout.
+func isTransientNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return false
+}
+
+// withRetry executes a request until it succeeds, fails with a non-retryable error,
+// exhausts the retry policy or reaches the client timeout.
+func (c *Client) withRetry(ctx context.Context, method string, do func() (*simpleresty.Response, error)) (*simpleresty.Response, error) {
+	policy := c.config.RetryPolicy
+
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if c.checkTimeout() {
+			return nil, errors.New(timeoutError)
+		}
+
+		response, err := do()
+
+		wait, retry := policy.shouldRetry(method, attempt, response, err)
+		if !retry {
+			return response, checkResponse(response, err)
+		}
+
+		// Do not sleep past the client budget or the operation deadline.
+		if deadline, ok := c.deadline(ctx); ok && time.Now().Add(wait).After(deadline) {
+			log.Printf("[DEBUG] Not retrying request as the deadline would be exceeded")
+			return response, checkResponse(response, err)
+		}
+
+		log.Printf("[DEBUG] Retrying request in %s (attempt %d of %d): %v", wait, attempt+1, policy.MaxAttempts,
+			checkResponse(response, err))
+		if err := sleepWithContext(ctx, wait); err != nil {
+			return response, err
+		}
+	}
+}
+
+// sleepWithContext waits for the given duration or until ctx is done, whichever happens first.
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"github.com/davidji99/simpleresty"
 )
 
@@ -20,6 +22,15 @@ type Segment struct {
 	Tags         []*Tag       `json:"tags,omitempty"`
 }
 
+// TagNames returns the names of the segment's tags.
+func (s *Segment) TagNames() []string {
+	names := make([]string, 0, len(s.Tags))
+	for _, t := range s.Tags {
+		names = append(names, t.GetName())
+	}
+	return names
+}
+
 // SegmentKeysList
 type SegmentKeysList struct {
 	Keys []*SegmentKey `json:"keys"`
@@ -41,26 +52,43 @@ type SegmentListResult struct {
 type SegmentRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Comment     string `json:"comment,omitempty"`
 }
 
 // List all segments.
 //
 // Reference: https://docs.split.io/reference#list-segments
-func (s *SegmentsService) List(workspaceID string) (*SegmentListResult, *simpleresty.Response, error) {
+func (s *SegmentsService) List(ctx context.Context, workspaceID string, opts ...interface{}) (*SegmentListResult, *simpleresty.Response, error) {
 	var result SegmentListResult
-	urlStr := s.client.http.RequestURL("/segments/ws/%s", workspaceID)
-	response, getErr := s.client.get(urlStr, &result, nil)
+	urlStr, err := s.client.http.RequestURLWithQueryParams(fmt.Sprintf("/segments/ws/%s", workspaceID), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, getErr := s.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
 
+// ListAll retrieves every segment in a workspace, following pagination.
+func (s *SegmentsService) ListAll(ctx context.Context, workspaceID string) ([]*Segment, *simpleresty.Response, error) {
+	return ListAllOffset(ctx, DefaultOffsetPageSize, func(ctx context.Context, params GenericListQueryParams) ([]*Segment, *GenericListResult, *simpleresty.Response, error) {
+		result, response, err := s.List(ctx, workspaceID, params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		return result.Objects, &result.GenericListResult, response, nil
+	})
+}
+
 // Get a segment.
 //
 // Reference: n/a
-func (s *SegmentsService) Get(workspaceID, name string) (*Segment, *simpleresty.Response, error) {
+func (s *SegmentsService) Get(ctx context.Context, workspaceID, name string) (*Segment, *simpleresty.Response, error) {
 	var result Segment
 	urlStr := s.client.http.RequestURL("/segments/ws/%s/%s", workspaceID, name)
-	response, getErr := s.client.get(urlStr, &result, nil)
+	response, getErr := s.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
@@ -68,27 +96,34 @@ func (s *SegmentsService) Get(workspaceID, name string) (*Segment, *simpleresty.
 // Create a segment. This API does not configure the Segment in any environment.
 //
 // Reference: https://docs.split.io/reference#create-segment
-func (s *SegmentsService) Create(workspaceID, trafficTypeID string, opts *SegmentRequest) (*Segment, *simpleresty.Response, error) {
+func (s *SegmentsService) Create(ctx context.Context, workspaceID, trafficTypeID string, opts *SegmentRequest) (*Segment, *simpleresty.Response, error) {
 	var result Segment
 	urlStr := s.client.http.RequestURL("/segments/ws/%s/trafficTypes/%s", workspaceID, trafficTypeID)
-	response, createErr := s.client.post(urlStr, &result, opts)
+	response, createErr := s.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
 
+// UpdateTags replaces the tags of a segment. An empty list removes all tags.
+//
+// Reference: https://docs.split.io/reference/associate-tags-with-an-object
+func (s *SegmentsService) UpdateTags(ctx context.Context, workspaceID, segmentName string, tags []string) (*simpleresty.Response, error) {
+	return s.client.associateTags(ctx, workspaceID, segmentName, TagObjectTypeSegment, tags)
+}
+
 // There exists an update (PUT) endpoint to modify the description but that is an internal endpoint:
 // https://app.split.io/internal/api/segmentMetadata/updateDescription/<SEGMENT_ID>
 //
 // TODO: implement this method whenever this endpoint is GA.
-//func (s *SegmentsService) Update() {
+//func (s *SegmentsService) Update(ctx context.Context) {
 //}
 
 // Delete a segment. This will automatically unconfigure the Segment Definition from all environments.
 //
 // Reference: https://docs.split.io/reference#delete-segment
-func (s *SegmentsService) Delete(workspaceID, segmentName string) (*simpleresty.Response, error) {
+func (s *SegmentsService) Delete(ctx context.Context, workspaceID, segmentName string) (*simpleresty.Response, error) {
 	urlStr := s.client.http.RequestURL("/segments/ws/%s/%s", workspaceID, segmentName)
-	response, deleteErr := s.client.delete(urlStr, nil, nil)
+	response, deleteErr := s.client.delete(ctx, urlStr, nil, nil)
 
 	return response, deleteErr
 }
@@ -96,10 +131,10 @@ func (s *SegmentsService) Delete(workspaceID, segmentName string) (*simpleresty.
 // Activate a Segment in an environment to be able to set its definitions.
 //
 // Reference: https://docs.split.io/reference#enable-segment-in-environment
-func (s *SegmentsService) Activate(environmentID, segmentName string) (*Segment, *simpleresty.Response, error) {
+func (s *SegmentsService) Activate(ctx context.Context, environmentID, segmentName string) (*Segment, *simpleresty.Response, error) {
 	var result Segment
 	urlStr := s.client.http.RequestURL("/segments/%s/%s", environmentID, segmentName)
-	response, err := s.client.post(urlStr, &result, nil)
+	response, err := s.client.post(ctx, urlStr, &result, nil)
 
 	return &result, response, err
 }
@@ -107,9 +142,9 @@ func (s *SegmentsService) Activate(environmentID, segmentName string) (*Segment,
 // Deactivate a Segment in an environment.
 //
 // Reference: https://docs.split.io/reference#deactivate-segment-in-environment
-func (s *SegmentsService) Deactivate(environmentID, segmentName string) (*simpleresty.Response, error) {
+func (s *SegmentsService) Deactivate(ctx context.Context, environmentID, segmentName string) (*simpleresty.Response, error) {
 	urlStr := s.client.http.RequestURL("/segments/%s/%s", environmentID, segmentName)
-	response, err := s.client.delete(urlStr, nil, nil)
+	response, err := s.client.delete(ctx, urlStr, nil, nil)
 
 	return response, err
 }
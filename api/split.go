@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
@@ -19,6 +20,7 @@ type Split struct {
 	Name                   *string             `json:"name"`
 	Description            *string             `json:"description"`
 	Tags                   []SplitTag          `json:"tags,omitempty"`
+	FlagSets               []*FlagSetIDRef     `json:"flagSets,omitempty"`
 	CreationTime           *int64              `json:"creationTime"`
 	RolloutStatusTimestamp *int64              `json:"rolloutStatusTimestamp"`
 	TrafficType            *TrafficType        `json:"trafficType"`
@@ -41,6 +43,8 @@ type Splits struct {
 type SplitCreateRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Comment     string `json:"comment,omitempty"`
+	Title       string `json:"title,omitempty"`
 }
 
 // SplitUpdateRequest represents a request to update a split.
@@ -53,10 +57,34 @@ type SplitTag struct {
 	Name string `json:"name"`
 }
 
+// SplitFlagSetsRequest represents a request to add a split to, or remove a split from, flag sets.
+type SplitFlagSetsRequest struct {
+	IDs     []string `json:"ids"`
+	Comment string   `json:"comment,omitempty"`
+}
+
+// FlagSetIDs returns the IDs of the flag sets the split belongs to.
+func (s *Split) FlagSetIDs() []string {
+	ids := make([]string, 0, len(s.FlagSets))
+	for _, f := range s.FlagSets {
+		ids = append(ids, f.GetID())
+	}
+	return ids
+}
+
+// TagNames returns the names of the split's tags.
+func (s *Split) TagNames() []string {
+	names := make([]string, 0, len(s.Tags))
+	for _, t := range s.Tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
 // List all splits.
 //
 // Reference: https://docs.split.io/reference/list-splits
-func (s *SplitsService) List(workspaceId string, opts ...interface{}) (*Splits, *simpleresty.Response, error) {
+func (s *SplitsService) List(ctx context.Context, workspaceId string, opts ...interface{}) (*Splits, *simpleresty.Response, error) {
 	var result Splits
 	urlStr, err := s.client.http.RequestURLWithQueryParams(fmt.Sprintf("/splits/ws/%s", workspaceId), opts...)
 	if err != nil {
@@ -64,20 +92,31 @@ func (s *SplitsService) List(workspaceId string, opts ...interface{}) (*Splits,
 	}
 
 	// Execute the request
-	response, getErr := s.client.get(urlStr, &result, nil)
+	response, getErr := s.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
 
+// ListAll retrieves every split in a workspace, following pagination.
+func (s *SplitsService) ListAll(ctx context.Context, workspaceId string) ([]*Split, *simpleresty.Response, error) {
+	return ListAllOffset(ctx, DefaultOffsetPageSize, func(ctx context.Context, params GenericListQueryParams) ([]*Split, *GenericListResult, *simpleresty.Response, error) {
+		result, response, err := s.List(ctx, workspaceId, params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		return result.Objects, &result.GenericListResult, response, nil
+	})
+}
+
 // Get a single split.
 //
 // splitId can be either the name or the UUID.
 //
 // Reference: https://docs.split.io/reference/get-split
-func (s *SplitsService) Get(workspaceId, splitId string) (*Split, *simpleresty.Response, error) {
+func (s *SplitsService) Get(ctx context.Context, workspaceId, splitId string) (*Split, *simpleresty.Response, error) {
 	var result Split
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s", workspaceId, splitId)
-	response, getErr := s.client.get(urlStr, &result, nil)
+	response, getErr := s.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
@@ -85,12 +124,12 @@ func (s *SplitsService) Get(workspaceId, splitId string) (*Split, *simpleresty.R
 // Create a single split.
 //
 // Reference: https://docs.split.io/reference/create-split
-func (s *SplitsService) Create(workspaceId, trafficTypeId string, opts *SplitCreateRequest) (*Split, *simpleresty.Response, error) {
+func (s *SplitsService) Create(ctx context.Context, workspaceId, trafficTypeId string, opts *SplitCreateRequest) (*Split, *simpleresty.Response, error) {
 	var result Split
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/trafficTypes/%s", workspaceId, trafficTypeId)
 
 	// Execute the request
-	response, createErr := s.client.post(urlStr, &result, opts)
+	response, createErr := s.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -98,14 +137,57 @@ func (s *SplitsService) Create(workspaceId, trafficTypeId string, opts *SplitCre
 // UpdateDescription of an existing split.
 //
 // Reference: https://docs.split.io/reference/update-split-description
-func (s *SplitsService) UpdateDescription(workspaceId, splitName, description string) (*Split, *simpleresty.Response, error) {
+func (s *SplitsService) UpdateDescription(ctx context.Context, workspaceId, splitName, description string) (*Split, *simpleresty.Response, error) {
 	var result Split
 
 	splitNameEncoded := url.QueryEscape(splitName)
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/updateDescription", workspaceId, splitNameEncoded)
 
 	// Execute the request
-	response, updateErr := s.client.put(urlStr, &result, description)
+	response, updateErr := s.client.put(ctx, urlStr, &result, description)
+
+	return &result, response, updateErr
+}
+
+// UpdateTags replaces the tags of an existing split. An empty list removes all tags.
+//
+// Split name is required, not the split UUID.
+//
+// Reference: https://docs.split.io/reference/associate-tags-with-an-object
+func (s *SplitsService) UpdateTags(ctx context.Context, workspaceId, splitName string, tags []string) (*simpleresty.Response, error) {
+	return s.client.associateTags(ctx, workspaceId, splitName, TagObjectTypeSplit, tags)
+}
+
+// AddToFlagSets adds an existing split to one or more flag sets.
+//
+// Split name is required, not the split UUID.
+//
+// Reference: https://docs.split.io/reference/add-feature-flag-to-flag-sets
+func (s *SplitsService) AddToFlagSets(ctx context.Context, workspaceId, splitName string, opts *SplitFlagSetsRequest) (*Split, *simpleresty.Response, error) {
+	var result Split
+
+	splitNameEncoded := url.QueryEscape(splitName)
+	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/addToFlagSets", workspaceId, splitNameEncoded)
+
+	// Execute the request
+	response, updateErr := s.client.put(ctx, urlStr, &result, opts)
+
+	return &result, response, updateErr
+}
+
+// RemoveFromFlagSets removes an existing split from one or more flag sets.
+//
+// Split name is required, not the split UUID.
+//
+// Reference: https://docs.split.io/reference/remove-feature-flag-from-flag-sets
+func (s *SplitsService) RemoveFromFlagSets(ctx context.Context, workspaceId, splitName string, opts *SplitFlagSetsRequest) (*Split, *simpleresty.Response, error) {
+	var result Split
+
+	splitNameEncoded := url.QueryEscape(splitName)
+	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/removeFromFlagSets", workspaceId, splitNameEncoded)
+
+	// Execute the request
+	response, updateErr := s.client.put(ctx, urlStr, &result, opts)
 
 	return &result, response, updateErr
 }
@@ -117,12 +199,12 @@ func (s *SplitsService) UpdateDescription(workspaceId, splitName, description st
 // Split name is required, not the split UUID.
 //
 // Reference: https://docs.split.io/reference/delete-split
-func (s *SplitsService) Delete(workspaceId, splitName string) (*simpleresty.Response, error) {
+func (s *SplitsService) Delete(ctx context.Context, workspaceId, splitName string) (*simpleresty.Response, error) {
 	splitNameEncoded := url.QueryEscape(splitName)
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s", workspaceId, splitNameEncoded)
 
 	// Execute the request
-	response, createErr := s.client.delete(urlStr, nil, nil)
+	response, createErr := s.client.delete(ctx, urlStr, nil, nil)
 
 	return response, createErr
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/davidji99/simpleresty"
@@ -39,6 +40,26 @@ type SplitDefinitionRequest struct {
 	TrafficAllocation int         `json:"trafficAllocation"`
 }
 
+// SplitKillRequest kills or restores a split definition.
+type SplitKillRequest struct {
+	Comment string `json:"comment,omitempty"`
+	Title   string `json:"title,omitempty"`
+}
+
+// SplitDefinitionPatch is a JSON patch operation applied to a split definition by a partial update.
+type SplitDefinitionPatch struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// SplitDefinitionPatchQueryParams records a title and comment with a partial update, whose body only
+// consists of patch operations.
+type SplitDefinitionPatchQueryParams struct {
+	Title   string `url:"title,omitempty"`
+	Comment string `url:"comment,omitempty"`
+}
+
 // Rule consists of a Condition and a list of Buckets.
 //
 // When the Split Definition is evaluated, if the Condition of this Rule is met, then the customer will be evaluated
@@ -73,22 +94,34 @@ type Condition struct {
 
 // Matcher represents the logic for selecting a specific subset of your customer population.
 type Matcher struct {
-	Negate    *bool       `json:"negate,omitempty"`
-	Type      *string     `json:"type,omitempty"`
-	Attribute *string     `json:"attribute,omitempty"`
-	String    *string     `json:"string,omitempty"`
-	Bool      *bool       `json:"bool,omitempty"`
-	Strings   []string    `json:"strings,omitempty"`
-	Number    *int        `json:"number,omitempty"`
-	Date      *int        `json:"date,omitempty"`
-	Between   interface{} `json:"between,omitempty"`
-	Depends   interface{} `json:"depends,omitempty"`
+	Negate    *bool    `json:"negate,omitempty"`
+	Type      *string  `json:"type,omitempty"`
+	Attribute *string  `json:"attribute,omitempty"`
+	String    *string  `json:"string,omitempty"`
+	Bool      *bool    `json:"bool,omitempty"`
+	Strings   []string `json:"strings,omitempty"`
+	Number    *int     `json:"number,omitempty"`
+	Date      *int     `json:"date,omitempty"`
+	Between   *Between `json:"between,omitempty"`
+	Depends   *Depends `json:"depends,omitempty"`
+}
+
+// Between is the inclusive range used by the BETWEEN_NUMBER and BETWEEN_DATE matchers.
+type Between struct {
+	From *int `json:"from"`
+	To   *int `json:"to"`
+}
+
+// Depends references the split and treatments used by the IN_SPLIT matcher.
+type Depends struct {
+	SplitName  *string  `json:"splitName"`
+	Treatments []string `json:"treatments"`
 }
 
 // ListDefinitions retrieves the Split Definitions given an environment.
 //
 // Reference: https://docs.split.io/reference/lists-split-definitions-in-environment
-func (s *SplitsService) ListDefinitions(workspaceId, environmentId string, opts ...interface{}) (*SplitDefinitions, *simpleresty.Response, error) {
+func (s *SplitsService) ListDefinitions(ctx context.Context, workspaceId, environmentId string, opts ...interface{}) (*SplitDefinitions, *simpleresty.Response, error) {
 	var result SplitDefinitions
 	// Here I have a problem with el requestURLWITHQUERy...
 	urlStr, err := s.client.http.RequestURLWithQueryParams(fmt.Sprintf("/splits/ws/%s/environments/%s", workspaceId, environmentId), opts...)
@@ -97,18 +130,29 @@ func (s *SplitsService) ListDefinitions(workspaceId, environmentId string, opts
 	}
 
 	// Execute the request
-	response, getErr := s.client.get(urlStr, &result, nil)
+	response, getErr := s.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
 
+// ListAllDefinitions retrieves every Split Definition in an environment, following pagination.
+func (s *SplitsService) ListAllDefinitions(ctx context.Context, workspaceId, environmentId string) ([]*SplitDefinition, *simpleresty.Response, error) {
+	return ListAllOffset(ctx, DefaultOffsetPageSize, func(ctx context.Context, params GenericListQueryParams) ([]*SplitDefinition, *GenericListResult, *simpleresty.Response, error) {
+		result, response, err := s.ListDefinitions(ctx, workspaceId, environmentId, params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		return result.Objects, &result.GenericListResult, response, nil
+	})
+}
+
 // GetDefinition retrieves a Split Definition given the name and the environment.
 //
 // Reference: https://docs.split.io/reference/get-split-definition-in-environment
-func (s *SplitsService) GetDefinition(workspaceId, splitName, environmentId string) (*SplitDefinition, *simpleresty.Response, error) {
+func (s *SplitsService) GetDefinition(ctx context.Context, workspaceId, splitName, environmentId string) (*SplitDefinition, *simpleresty.Response, error) {
 	var result SplitDefinition
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/environments/%s", workspaceId, splitName, environmentId)
-	response, getErr := s.client.get(urlStr, &result, nil)
+	response, getErr := s.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
@@ -116,12 +160,12 @@ func (s *SplitsService) GetDefinition(workspaceId, splitName, environmentId stri
 // CreateDefinition configures a Split Definition for a specific environment.
 //
 // Reference: https://docs.split.io/reference/create-split-definition-in-environment
-func (s *SplitsService) CreateDefinition(workspaceId, splitName, environmentId string, opts *SplitDefinitionRequest) (*SplitDefinition, *simpleresty.Response, error) {
+func (s *SplitsService) CreateDefinition(ctx context.Context, workspaceId, splitName, environmentId string, opts *SplitDefinitionRequest) (*SplitDefinition, *simpleresty.Response, error) {
 	var result SplitDefinition
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/environments/%s", workspaceId, splitName, environmentId)
 
 	// Execute the request
-	response, createErr := s.client.post(urlStr, &result, opts)
+	response, createErr := s.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -129,24 +173,68 @@ func (s *SplitsService) CreateDefinition(workspaceId, splitName, environmentId s
 // UpdateDefinitionFull performs a full update of a Split Definition for a specific environment.
 //
 // Reference: https://docs.split.io/reference/full-update-split-definition-in-environment
-func (s *SplitsService) UpdateDefinitionFull(workspaceId, splitName, environmentId string, opts *SplitDefinitionRequest) (*SplitDefinition, *simpleresty.Response, error) {
+func (s *SplitsService) UpdateDefinitionFull(ctx context.Context, workspaceId, splitName, environmentId string, opts *SplitDefinitionRequest) (*SplitDefinition, *simpleresty.Response, error) {
 	var result SplitDefinition
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/environments/%s", workspaceId, splitName, environmentId)
 
 	// Execute the request
-	response, createErr := s.client.put(urlStr, &result, opts)
+	response, createErr := s.client.put(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
 
+// UpdateDefinitionPartial performs a partial update of a Split Definition for a specific environment.
+// Only the paths referenced by the operations are modified, leaving the remainder of the definition untouched.
+//
+// Reference: https://docs.split.io/reference/partial-update-split-definition-in-environment
+func (s *SplitsService) UpdateDefinitionPartial(ctx context.Context, workspaceId, splitName, environmentId string, patch []SplitDefinitionPatch, opts ...interface{}) (*SplitDefinition, *simpleresty.Response, error) {
+	var result SplitDefinition
+	urlStr, err := s.client.http.RequestURLWithQueryParams(fmt.Sprintf("/splits/ws/%s/%s/environments/%s", workspaceId, splitName, environmentId), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Execute the request
+	response, updateErr := s.client.patch(ctx, urlStr, &result, patch)
+
+	return &result, response, updateErr
+}
+
+// Kill a Split Definition in a specific environment. Every customer is served the default treatment until
+// the Split Definition is restored.
+//
+// Reference: https://docs.split.io/reference/kill-split-in-environment
+func (s *SplitsService) Kill(ctx context.Context, workspaceId, splitName, environmentId string, opts *SplitKillRequest) (*SplitDefinition, *simpleresty.Response, error) {
+	var result SplitDefinition
+	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/environments/%s/kill", workspaceId, splitName, environmentId)
+
+	// Execute the request
+	response, killErr := s.client.put(ctx, urlStr, &result, opts)
+
+	return &result, response, killErr
+}
+
+// Restore a killed Split Definition in a specific environment.
+//
+// Reference: https://docs.split.io/reference/restore-split-in-environment
+func (s *SplitsService) Restore(ctx context.Context, workspaceId, splitName, environmentId string, opts *SplitKillRequest) (*SplitDefinition, *simpleresty.Response, error) {
+	var result SplitDefinition
+	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/environments/%s/restore", workspaceId, splitName, environmentId)
+
+	// Execute the request
+	response, restoreErr := s.client.put(ctx, urlStr, &result, opts)
+
+	return &result, response, restoreErr
+}
+
 // RemoveDefinition removes a Split Definition for a specific environment.
 //
 // Reference: https://docs.split.io/reference/remove-split-definition-from-environment
-func (s *SplitsService) RemoveDefinition(workspaceId, splitName, environmentId string) (*simpleresty.Response, error) {
+func (s *SplitsService) RemoveDefinition(ctx context.Context, workspaceId, splitName, environmentId string) (*simpleresty.Response, error) {
 	urlStr := s.client.http.RequestURL("/splits/ws/%s/%s/environments/%s", workspaceId, splitName, environmentId)
 
 	// Execute the request
-	response, createErr := s.client.delete(urlStr, nil, nil)
+	response, createErr := s.client.delete(ctx, urlStr, nil, nil)
 
 	return response, createErr
 }
@@ -1,5 +1,36 @@
 package api
 
+import (
+	"context"
+	"net/url"
+
+	"github.com/davidji99/simpleresty"
+)
+
+const (
+	// TagObjectTypeSplit is the object type of tags associated with a split.
+	TagObjectTypeSplit = "Split"
+
+	// TagObjectTypeSegment is the object type of tags associated with a segment.
+	TagObjectTypeSegment = "Segment"
+)
+
 type Tag struct {
 	Name *string `json:"name"`
 }
+
+// associateTags replaces every tag of an object with the given tags. An empty list removes all tags.
+//
+// Reference: https://docs.split.io/reference/associate-tags-with-an-object
+func (c *Client) associateTags(ctx context.Context, workspaceID, objectName, objectType string, tags []string) (*simpleresty.Response, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	urlStr := c.http.RequestURL("/tags/ws/%s/object/%s/objecttype/%s", workspaceID, url.QueryEscape(objectName), objectType)
+
+	// Execute the request
+	response, err := c.post(ctx, urlStr, nil, tags)
+
+	return response, err
+}
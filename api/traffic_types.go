@@ -1,8 +1,7 @@
 package api
 
 import (
-	"fmt"
-
+	"context"
 	"github.com/davidji99/simpleresty"
 )
 
@@ -29,17 +28,17 @@ type TrafficTypeRequest struct {
 // List all traffic types.
 //
 // Reference: https://docs.split.io/reference#get-traffic-types
-func (t *TrafficTypesService) List(workspaceID string) ([]*TrafficType, *simpleresty.Response, error) {
+func (t *TrafficTypesService) List(ctx context.Context, workspaceID string) ([]*TrafficType, *simpleresty.Response, error) {
 	var result []*TrafficType
 	urlStr := t.client.http.RequestURL("/trafficTypes/ws/%s", workspaceID)
-	response, getErr := t.client.get(urlStr, &result, nil)
+	response, getErr := t.client.get(ctx, urlStr, &result, nil)
 
 	return result, response, getErr
 }
 
 // FindByID retrieves a traffic type by its ID.
-func (t *TrafficTypesService) FindByID(workspaceID, trafficTypeID string) (*TrafficType, *simpleresty.Response, error) {
-	trafficTypes, response, listErr := t.List(workspaceID)
+func (t *TrafficTypesService) FindByID(ctx context.Context, workspaceID, trafficTypeID string) (*TrafficType, *simpleresty.Response, error) {
+	trafficTypes, response, listErr := t.List(ctx, workspaceID)
 	if listErr != nil {
 		return nil, response, listErr
 	}
@@ -50,12 +49,12 @@ func (t *TrafficTypesService) FindByID(workspaceID, trafficTypeID string) (*Traf
 		}
 	}
 
-	return nil, nil, fmt.Errorf("traffic type [%s] not found", trafficTypeID)
+	return nil, nil, newNotFoundError("traffic type", trafficTypeID)
 }
 
 // FindByName retrieves a traffic type by its name.
-func (t *TrafficTypesService) FindByName(workspaceID, trafficTypeName string) (*TrafficType, *simpleresty.Response, error) {
-	trafficTypes, response, listErr := t.List(workspaceID)
+func (t *TrafficTypesService) FindByName(ctx context.Context, workspaceID, trafficTypeName string) (*TrafficType, *simpleresty.Response, error) {
+	trafficTypes, response, listErr := t.List(ctx, workspaceID)
 	if listErr != nil {
 		return nil, response, listErr
 	}
@@ -66,18 +65,18 @@ func (t *TrafficTypesService) FindByName(workspaceID, trafficTypeName string) (*
 		}
 	}
 
-	return nil, nil, fmt.Errorf("traffic type [%s] not found", trafficTypeName)
+	return nil, nil, newNotFoundError("traffic type", trafficTypeName)
 }
 
 // Create a traffic type.
 //
 // Reference: https://docs.split.io/reference/create-traffic-types
-func (t *TrafficTypesService) Create(workspaceID string, opts *TrafficTypeRequest) (*TrafficType, *simpleresty.Response, error) {
+func (t *TrafficTypesService) Create(ctx context.Context, workspaceID string, opts *TrafficTypeRequest) (*TrafficType, *simpleresty.Response, error) {
 	var result TrafficType
 	urlStr := t.client.http.RequestURL("/trafficTypes/ws/%s", workspaceID)
 
 	// Execute the request
-	response, createErr := t.client.post(urlStr, &result, opts)
+	response, createErr := t.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -85,11 +84,11 @@ func (t *TrafficTypesService) Create(workspaceID string, opts *TrafficTypeReques
 // Delete a traffic type.
 //
 // Reference: https://docs.split.io/reference/delete-trafic-type
-func (t *TrafficTypesService) Delete(trafficTypeID string) (*simpleresty.Response, error) {
+func (t *TrafficTypesService) Delete(ctx context.Context, trafficTypeID string) (*simpleresty.Response, error) {
 	urlStr := t.client.http.RequestURL("/trafficTypes/%s", trafficTypeID)
 
 	// Execute the request
-	response, deleteErr := t.client.delete(urlStr, nil, nil)
+	response, deleteErr := t.client.delete(ctx, urlStr, nil, nil)
 
 	return response, deleteErr
 }
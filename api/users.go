@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"github.com/davidji99/simpleresty"
 )
 
@@ -39,22 +40,50 @@ type UserListOpts struct {
 	Limit int `url:"limit,omitempty"`
 
 	// value of "previousMarker" in response
-	Before int `url:"limit,omitempty"`
+	Before string `url:"before,omitempty"`
 
 	// value of "nextMarker" in response
-	After string `url:"limit,omitempty"`
+	After string `url:"after,omitempty"`
 
-	// eturns Active members of a group
-	GroupID string `url:"limit,omitempty"`
+	// returns Active members of a group
+	GroupID string `url:"group_id,omitempty"`
 }
 
 // UserCreateRequest is to create a new user.
 type UserCreateRequest struct {
-	Email  string `json:"email,omitempty"`
-	Groups []struct {
-		ID   string `json:"id,omitempty"`
-		Type string `json:"type,omitempty"`
-	} `json:"groups,omitempty"`
+	Email  string         `json:"email,omitempty"`
+	Groups []UserGroupRef `json:"groups,omitempty"`
+}
+
+// UserGroupRef references a group a user is part of.
+type UserGroupRef struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// UserGroupsPatch is a JSON patch operation on the groups of a user.
+type UserGroupsPatch struct {
+	Op    string         `json:"op"`
+	Path  string         `json:"path"`
+	Value []UserGroupRef `json:"value"`
+}
+
+// NewUserGroupRefs returns references to the groups with the given IDs.
+func NewUserGroupRefs(groupIDs []string) []UserGroupRef {
+	refs := make([]UserGroupRef, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		refs = append(refs, UserGroupRef{ID: id, Type: "group"})
+	}
+	return refs
+}
+
+// GroupIDs returns the IDs of the groups the user is part of.
+func (u *User) GroupIDs() []string {
+	ids := make([]string, 0, len(u.Groups))
+	for _, g := range u.Groups {
+		ids = append(ids, g.GetID())
+	}
+	return ids
 }
 
 // UserUpdateRequest updates an existing user.
@@ -70,7 +99,7 @@ type UserUpdateRequest struct {
 // By default, pending users are not returned via this endpoint.
 //
 // Reference: https://docs.split.io/reference#list-users
-func (u *UsersService) List(opts *UserListOpts) (*UserListResult, *simpleresty.Response, error) {
+func (u *UsersService) List(ctx context.Context, opts *UserListOpts) (*UserListResult, *simpleresty.Response, error) {
 	var result UserListResult
 	urlStr, urlStrErr := u.client.http.RequestURLWithQueryParams("/users", opts)
 	if urlStrErr != nil {
@@ -78,18 +107,38 @@ func (u *UsersService) List(opts *UserListOpts) (*UserListResult, *simpleresty.R
 	}
 
 	// Execute the request
-	response, getErr := u.client.get(urlStr, &result, nil)
+	response, getErr := u.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
 
+// ListAll retrieves every user matching opts, following the pagination markers.
+func (u *UsersService) ListAll(ctx context.Context, opts *UserListOpts) ([]*User, *simpleresty.Response, error) {
+	params := UserListOpts{}
+	if opts != nil {
+		params = *opts
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultMarkerPageSize
+	}
+
+	return ListAllMarker(ctx, func(ctx context.Context, marker string) ([]*User, *string, *simpleresty.Response, error) {
+		params.After = marker
+		result, response, err := u.List(ctx, &params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		return result.Data, result.NextMarker, response, nil
+	})
+}
+
 // Get a user by their user Id.
 //
 // Reference: https://docs.split.io/reference#get-user
-func (u *UsersService) Get(id string) (*User, *simpleresty.Response, error) {
+func (u *UsersService) Get(ctx context.Context, id string) (*User, *simpleresty.Response, error) {
 	var result User
 	urlStr := u.client.http.RequestURL("/users/%s", id)
-	response, getErr := u.client.get(urlStr, &result, nil)
+	response, getErr := u.client.get(ctx, urlStr, &result, nil)
 
 	return &result, response, getErr
 }
@@ -97,12 +146,12 @@ func (u *UsersService) Get(id string) (*User, *simpleresty.Response, error) {
 // Invite a new user to your organization. They will be created with a Pending status
 //
 // Reference: https://docs.split.io/reference#invite-a-new-user
-func (u *UsersService) Invite(opts *UserCreateRequest) (*User, *simpleresty.Response, error) {
+func (u *UsersService) Invite(ctx context.Context, opts *UserCreateRequest) (*User, *simpleresty.Response, error) {
 	var result User
 	urlStr := u.client.http.RequestURL("/users")
 
 	// Execute the request
-	response, err := u.client.post(urlStr, &result, opts)
+	response, err := u.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, err
 }
@@ -110,38 +159,39 @@ func (u *UsersService) Invite(opts *UserCreateRequest) (*User, *simpleresty.Resp
 // Update display name, email, disable 2FA, and Activate/Deactivate of a User.
 //
 // Reference: https://docs.split.io/reference#full-update-user
-func (u *UsersService) Update(id string, opts *UserUpdateRequest) (*User, *simpleresty.Response, error) {
+func (u *UsersService) Update(ctx context.Context, id string, opts *UserUpdateRequest) (*User, *simpleresty.Response, error) {
 	var result User
 	urlStr := u.client.http.RequestURL("/users/%s", id)
 
 	// Execute the request
-	response, err := u.client.put(urlStr, &result, opts)
+	response, err := u.client.put(ctx, urlStr, &result, opts)
 
 	return &result, response, err
 }
 
-//// UpdateUserGroups Use this endpoint to update the groups that a user is part of.
-////
-//// Reference: https://docs.split.io/reference#update-users-groups
-//func (s *UsersService) UpdateUserGroups(id string, opts *UserUpdateRequest) (*User, *simpleresty.Response, error) {
-//	var result User
-//	urlStr := s.client.http.RequestURL("/users/%s", id)
-//
-//	// Execute the request
-//	response, err := s.client.put(urlStr, &result, opts)
+// UpdateUserGroups replaces the groups that a user is part of. An empty list removes the user from every group.
 //
-//	return &result, response, err
-//}
+// Reference: https://docs.split.io/reference#update-users-groups
+func (u *UsersService) UpdateUserGroups(ctx context.Context, id string, groupIDs []string) (*User, *simpleresty.Response, error) {
+	var result User
+	urlStr := u.client.http.RequestURL("/users/%s", id)
+	opts := []UserGroupsPatch{{Op: "replace", Path: "/groups", Value: NewUserGroupRefs(groupIDs)}}
+
+	// Execute the request
+	response, err := u.client.patch(ctx, urlStr, &result, opts)
+
+	return &result, response, err
+}
 
 // DeletePendingUser that have not accepted their invites yet. Once a user is active,
 // you can only deactivate the user via a PUT request
 //
 // Reference: https://docs.split.io/reference#delete-a-pending-user
-func (u *UsersService) DeletePendingUser(id string) (*simpleresty.Response, error) {
+func (u *UsersService) DeletePendingUser(ctx context.Context, id string) (*simpleresty.Response, error) {
 	urlStr := u.client.http.RequestURL("/users/%s", id)
 
 	// Execute the request
-	response, deleteErr := u.client.delete(urlStr, nil, nil)
+	response, deleteErr := u.client.delete(ctx, urlStr, nil, nil)
 
 	return response, deleteErr
 }
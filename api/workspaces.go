@@ -1,8 +1,7 @@
 package api
 
 import (
-	"fmt"
-
+	"context"
 	"github.com/davidji99/simpleresty"
 )
 
@@ -53,7 +52,7 @@ type WorkspaceListQueryParams struct {
 // List all workspaces.
 //
 // Reference: https://docs.split.io/reference#get-workspaces
-func (w *WorkspacesService) List(opts ...interface{}) (*Workspaces, *simpleresty.Response, error) {
+func (w *WorkspacesService) List(ctx context.Context, opts ...interface{}) (*Workspaces, *simpleresty.Response, error) {
 	var result *Workspaces
 	//
 	urlStr, err := w.client.http.RequestURLWithQueryParams("/workspaces", opts...)
@@ -62,39 +61,57 @@ func (w *WorkspacesService) List(opts ...interface{}) (*Workspaces, *simpleresty
 	}
 
 	// Execute the request
-	response, getErr := w.client.get(urlStr, &result, nil)
+	response, getErr := w.client.get(ctx, urlStr, &result, nil)
 
 	return result, response, getErr
 }
 
+// ListAll retrieves every workspace matching the optional query parameters, following pagination.
+func (w *WorkspacesService) ListAll(ctx context.Context, opts *WorkspaceListQueryParams) ([]*Workspace, *simpleresty.Response, error) {
+	params := WorkspaceListQueryParams{}
+	if opts != nil {
+		params = *opts
+	}
+
+	return ListAllOffset(ctx, DefaultOffsetPageSize, func(ctx context.Context, page GenericListQueryParams) ([]*Workspace, *GenericListResult, *simpleresty.Response, error) {
+		params.GenericListQueryParams = page
+		result, response, err := w.List(ctx, params)
+		if err != nil {
+			return nil, nil, response, err
+		}
+		if result == nil {
+			return nil, nil, response, nil
+		}
+		return result.Objects, &result.GenericListResult, response, nil
+	})
+}
+
 // FindById retrieves a workspace by its ID.
 //
 // Note: this method uses the List() method to first return all workspaces and then look for the target workspace
 // by an ID. The Split APIv2 does not provide a GET#show endpoint for workspaces, unfortunately.
-func (w *WorkspacesService) FindById(id string) (*Workspace, *simpleresty.Response, error) {
-	workspaces, listResponse, listErr := w.List()
+func (w *WorkspacesService) FindById(ctx context.Context, id string) (*Workspace, *simpleresty.Response, error) {
+	workspaces, listResponse, listErr := w.ListAll(ctx, nil)
 	if listErr != nil {
 		return nil, listResponse, listErr
 	}
 
-	if workspaces.HasObjects() {
-		for _, w := range workspaces.Objects {
-			if w.GetID() == id {
-				return w, nil, nil
-			}
+	for _, w := range workspaces {
+		if w.GetID() == id {
+			return w, nil, nil
 		}
 	}
 
-	return nil, nil, fmt.Errorf("workspace [%s] not found", id)
+	return nil, nil, newNotFoundError("workspace", id)
 }
 
 // FindByName retrieves a workspace by its name.
 //
 // This method uses List query parameters to find an exact match.
-func (w *WorkspacesService) FindByName(name string) (*Workspace, *simpleresty.Response, error) {
+func (w *WorkspacesService) FindByName(ctx context.Context, name string) (*Workspace, *simpleresty.Response, error) {
 	params := WorkspaceListQueryParams{Name: name, NameOp: "IS"}
 
-	workspaces, listResponse, listErr := w.List(params)
+	workspaces, listResponse, listErr := w.List(ctx, params)
 	if listErr != nil {
 		return nil, listResponse, listErr
 	}
@@ -105,7 +122,7 @@ func (w *WorkspacesService) FindByName(name string) (*Workspace, *simpleresty.Re
 		}
 	}
 
-	return nil, nil, fmt.Errorf("workspace [%s] not found", name)
+	return nil, nil, newNotFoundError("workspace", name)
 }
 
 // Create a workspaces.
@@ -114,12 +131,12 @@ func (w *WorkspacesService) FindByName(name string) (*Workspace, *simpleresty.Re
 // You must use the create environment API to create an environment.
 //
 // Reference: https://docs.split.io/reference/create-workspace
-func (w *WorkspacesService) Create(opts *WorkspaceRequest) (*Workspace, *simpleresty.Response, error) {
+func (w *WorkspacesService) Create(ctx context.Context, opts *WorkspaceRequest) (*Workspace, *simpleresty.Response, error) {
 	var result Workspace
 	urlStr := w.client.http.RequestURL("/workspaces")
 
 	// Execute the request
-	response, createErr := w.client.post(urlStr, &result, opts)
+	response, createErr := w.client.post(ctx, urlStr, &result, opts)
 
 	return &result, response, createErr
 }
@@ -127,7 +144,7 @@ func (w *WorkspacesService) Create(opts *WorkspaceRequest) (*Workspace, *simpler
 // Update a workspaces.
 //
 // Reference: https://docs.split.io/reference/update-workspace
-func (w *WorkspacesService) Update(id string, opts *WorkspaceRequest) (*Workspace, *simpleresty.Response, error) {
+func (w *WorkspacesService) Update(ctx context.Context, id string, opts *WorkspaceRequest) (*Workspace, *simpleresty.Response, error) {
 	var result Workspace
 	urlStr := w.client.http.RequestURL("/workspaces/%s", id)
 
@@ -149,7 +166,7 @@ func (w *WorkspacesService) Update(id string, opts *WorkspaceRequest) (*Workspac
 	}
 
 	// Execute the request
-	response, updateErr := w.client.patch(urlStr, &result, optsFull)
+	response, updateErr := w.client.patch(ctx, urlStr, &result, optsFull)
 
 	return &result, response, updateErr
 }
@@ -157,11 +174,11 @@ func (w *WorkspacesService) Update(id string, opts *WorkspaceRequest) (*Workspac
 // Delete a workspace.
 //
 // Reference: https://docs.split.io/reference/delete-workspace
-func (w *WorkspacesService) Delete(id string) (*simpleresty.Response, error) {
+func (w *WorkspacesService) Delete(ctx context.Context, id string) (*simpleresty.Response, error) {
 	urlStr := w.client.http.RequestURL("/workspaces/%s", id)
 
 	// Execute the request
-	response, deleteErr := w.client.delete(urlStr, nil, nil)
+	response, deleteErr := w.client.delete(ctx, urlStr, nil, nil)
 
 	return response, deleteErr
 }
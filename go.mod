@@ -7,6 +7,7 @@ toolchain go1.24.3
 require (
 	github.com/davidji99/simpleresty v0.4.2
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.37.0
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.3
 )
 
 require (
@@ -0,0 +1,439 @@
+package fakesplit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/davidji99/terraform-provider-split/api"
+)
+
+func newTestClient(t *testing.T, s *Server) *api.Client {
+	t.Helper()
+
+	client, err := api.New(
+		api.APIKey("fake-api-key"),
+		api.APIBaseURL(s.APIBaseURL()),
+		api.APIv3BaseURL(s.APIv3BaseURL()),
+		api.RetryBackoff(time.Millisecond, 10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+
+	return client
+}
+
+func TestServer_SplitDefinitionLifecycle(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	fixtures := s.Seed()
+	client := newTestClient(t, s)
+	ctx := context.Background()
+	workspaceID := fixtures.Workspace.GetID()
+	environmentID := fixtures.Environment.GetID()
+
+	split, _, err := client.Splits.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SplitCreateRequest{Name: "my-split"})
+	if err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	if _, _, err := client.Splits.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SplitCreateRequest{Name: "my-split"}); !api.IsConflict(err) {
+		t.Fatalf("expected a conflict error, got: %v", err)
+	}
+
+	on, off := "on", "off"
+	size := 100
+	req := &api.SplitDefinitionRequest{
+		Treatments:        []api.Treatment{{Name: &on}, {Name: &off}},
+		DefaultRule:       []api.Bucket{{Treatment: &off, Size: &size}},
+		DefaultTreatment:  off,
+		TrafficAllocation: 77,
+	}
+
+	if _, _, err := client.Splits.CreateDefinition(ctx, workspaceID, split.GetName(), environmentID, req); err != nil {
+		t.Fatalf("unable to create split definition: %s", err)
+	}
+
+	def, _, err := client.Splits.GetDefinition(ctx, workspaceID, split.GetName(), environmentID)
+	if err != nil {
+		t.Fatalf("unable to get split definition: %s", err)
+	}
+	if def.GetTrafficAllocation() != 77 || len(def.Treatments) != 2 || def.GetEnvironment().GetID() != environmentID {
+		t.Fatalf("unexpected split definition: %+v", def)
+	}
+
+	patched, _, err := client.Splits.UpdateDefinitionPartial(ctx, workspaceID, split.GetName(), environmentID, []api.SplitDefinitionPatch{
+		{Op: "replace", Path: "/trafficAllocation", Value: 0},
+	})
+	if err != nil {
+		t.Fatalf("unable to partially update split definition: %s", err)
+	}
+	if patched.GetTrafficAllocation() != 0 || len(patched.Treatments) != 2 || patched.GetDefaultTreatment() != off {
+		t.Fatalf("unexpected split definition: %+v", patched)
+	}
+
+	killed, _, err := client.Splits.Kill(ctx, workspaceID, split.GetName(), environmentID, &api.SplitKillRequest{})
+	if err != nil || !killed.GetKilled() {
+		t.Fatalf("expected split definition to be killed, got: %v", err)
+	}
+
+	restored, _, err := client.Splits.Restore(ctx, workspaceID, split.GetName(), environmentID, &api.SplitKillRequest{})
+	if err != nil || restored.GetKilled() {
+		t.Fatalf("expected split definition to be restored, got: %v", err)
+	}
+
+	if _, err := client.Splits.Delete(ctx, workspaceID, split.GetName()); err != nil {
+		t.Fatalf("unable to delete split: %s", err)
+	}
+
+	if _, _, err := client.Splits.GetDefinition(ctx, workspaceID, split.GetName(), environmentID); !api.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
+
+func TestServer_SegmentKeys(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	fixtures := s.Seed()
+	client := newTestClient(t, s)
+	ctx := context.Background()
+	environmentID := fixtures.Environment.GetID()
+
+	if _, _, err := client.Segments.Create(ctx, fixtures.Workspace.GetID(), fixtures.TrafficType.GetID(), &api.SegmentRequest{Name: "beta"}); err != nil {
+		t.Fatalf("unable to create segment: %s", err)
+	}
+
+	if _, _, err := client.Environments.AddSegmentKeys(ctx, environmentID, "beta", false, &api.EnvironmentSegmentKeysRequest{Keys: []string{"a"}}); !api.IsNotFound(err) {
+		t.Fatalf("expected a not found error for an inactive segment, got: %v", err)
+	}
+
+	if _, _, err := client.Segments.Activate(ctx, environmentID, "beta"); err != nil {
+		t.Fatalf("unable to activate segment: %s", err)
+	}
+
+	if _, _, err := client.Environments.AddSegmentKeys(ctx, environmentID, "beta", false, &api.EnvironmentSegmentKeysRequest{Keys: []string{"c", "a", "b"}}); err != nil {
+		t.Fatalf("unable to add segment keys: %s", err)
+	}
+
+	if _, err := client.Environments.RemoveSegmentKeys(ctx, environmentID, "beta", &api.EnvironmentSegmentKeysRequest{Keys: []string{"b"}}); err != nil {
+		t.Fatalf("unable to remove segment keys: %s", err)
+	}
+
+	keys, _, err := client.Environments.GetSegmentKeys(ctx, environmentID, "beta")
+	if err != nil {
+		t.Fatalf("unable to get segment keys: %s", err)
+	}
+	if len(keys.Keys) != 2 || keys.Keys[0].GetKey() != "a" || keys.Keys[1].GetKey() != "c" {
+		t.Fatalf("unexpected segment keys: %+v", keys.Keys)
+	}
+
+	segments, _, err := client.Environments.ListAllSegments(ctx, fixtures.Workspace.GetID(), environmentID)
+	if err != nil {
+		t.Fatalf("unable to list segments: %s", err)
+	}
+	if len(segments) != 1 || segments[0].GetEnvironment().GetID() != environmentID {
+		t.Fatalf("unexpected segments: %+v", segments)
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	fixtures := s.Seed()
+	client := newTestClient(t, s)
+	ctx := context.Background()
+	workspaceID := fixtures.Workspace.GetID()
+
+	for i := 0; i < 120; i++ {
+		name := fmt.Sprintf("split-%03d", i)
+		if _, _, err := client.Splits.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SplitCreateRequest{Name: name}); err != nil {
+			t.Fatalf("unable to create split: %s", err)
+		}
+	}
+
+	splits, _, err := client.Splits.ListAll(ctx, workspaceID)
+	if err != nil {
+		t.Fatalf("unable to list splits: %s", err)
+	}
+	if len(splits) != 120 {
+		t.Fatalf("expected 120 splits, got %d", len(splits))
+	}
+
+	for i := 0; i < 250; i++ {
+		name := fmt.Sprintf("fs-%03d", i)
+		opts := &api.FlagSetRequest{
+			Name:      &name,
+			Workspace: &api.WorkspaceIDRef{ID: fixtures.Workspace.ID},
+		}
+		if _, _, err := client.FlagSets.Create(ctx, opts); err != nil {
+			t.Fatalf("unable to create flag set: %s", err)
+		}
+	}
+
+	flagSets, _, err := client.FlagSets.List(ctx, workspaceID)
+	if err != nil {
+		t.Fatalf("unable to list flag sets: %s", err)
+	}
+	if len(flagSets) != 250 {
+		t.Fatalf("expected 250 flag sets, got %d", len(flagSets))
+	}
+}
+
+func TestServer_InjectFault(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	fixtures := s.Seed()
+	client := newTestClient(t, s)
+	ctx := context.Background()
+
+	// Rate limited requests are retried.
+	s.InjectRateLimit(http.MethodGet, "/environments", 2, 0)
+	if _, _, err := client.Environments.List(ctx, fixtures.Workspace.GetID()); err != nil {
+		t.Fatalf("expected the request to succeed after retries, got: %s", err)
+	}
+
+	attempts := 0
+	for _, r := range s.Requests() {
+		if r.Path == "/environments/ws/"+fixtures.Workspace.GetID() {
+			attempts++
+		}
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+
+	// Faults are limited to the given number of requests.
+	s.InjectNotFound(http.MethodGet, "/users/", 1)
+	if _, _, err := client.Users.Get(ctx, fixtures.User.GetID()); !api.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+	if _, _, err := client.Users.Get(ctx, fixtures.User.GetID()); err != nil {
+		t.Fatalf("expected the fault to be cleared, got: %s", err)
+	}
+
+	// Persistent faults apply until cleared.
+	s.InjectConflict(http.MethodPost, "/groups", 0)
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Groups.Create(ctx, &api.GroupRequest{Name: "admins"}); !api.IsConflict(err) {
+			t.Fatalf("expected a conflict error, got: %v", err)
+		}
+	}
+
+	s.ClearFaults()
+	if _, _, err := client.Groups.Create(ctx, &api.GroupRequest{Name: "admins"}); err != nil {
+		t.Fatalf("unable to create group: %s", err)
+	}
+}
+
+func TestServer_RetryMethods(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	s.Seed()
+	client := newTestClient(t, s)
+	ctx := context.Background()
+
+	countRequests := func(method, path string) int {
+		n := 0
+		for _, r := range s.Requests() {
+			if r.Method == method && r.Path == path {
+				n++
+			}
+		}
+		return n
+	}
+
+	// Idempotent requests are retried on gateway errors.
+	s.InjectFault(Fault{Method: http.MethodGet, PathPrefix: "/groups", StatusCode: http.StatusServiceUnavailable, Times: 1})
+	if _, _, err := client.Groups.List(ctx, nil); err != nil {
+		t.Fatalf("expected the request to succeed after a retry, got: %s", err)
+	}
+
+	// Requests that are not idempotent are not retried on gateway errors as they may have been applied.
+	s.InjectFault(Fault{Method: http.MethodPost, PathPrefix: "/groups", StatusCode: http.StatusServiceUnavailable, Times: 1})
+	if _, _, err := client.Groups.Create(ctx, &api.GroupRequest{Name: "admins"}); api.StatusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("expected a service unavailable error, got: %v", err)
+	}
+	if n := countRequests(http.MethodPost, "/groups"); n != 1 {
+		t.Fatalf("expected 1 attempt, got %d", n)
+	}
+
+	// Requests that are not idempotent are retried when rate limited, waiting no longer than the maximum backoff.
+	s.InjectRateLimit(http.MethodPost, "/groups", 1, 3600)
+	start := time.Now()
+	if _, _, err := client.Groups.Create(ctx, &api.GroupRequest{Name: "admins"}); err != nil {
+		t.Fatalf("expected the request to succeed after a retry, got: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the rate limit wait to be capped, waited %s", elapsed)
+	}
+	if n := countRequests(http.MethodPost, "/groups"); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestServer_ChangeRequests(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	fixtures := s.Seed()
+	client := newTestClient(t, s)
+	ctx := context.Background()
+	workspaceID := fixtures.Workspace.GetID()
+	environmentID := fixtures.Environment.GetID()
+
+	if _, _, err := client.Splits.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SplitCreateRequest{Name: "my-split"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	on, off := "on", "off"
+	size, allocation := 100, 50
+	req := &api.ChangeRequestRequest{
+		Split: &api.ChangeRequestSplit{
+			Name:              "my-split",
+			Treatments:        []api.Treatment{{Name: &on}, {Name: &off}},
+			DefaultRule:       []api.Bucket{{Treatment: &off, Size: &size}},
+			DefaultTreatment:  off,
+			TrafficAllocation: &allocation,
+		},
+		OperationType: api.ChangeRequestOperationCreate,
+		Comment:       "please review",
+	}
+
+	cr, _, err := client.ChangeRequests.Create(ctx, workspaceID, environmentID, req)
+	if err != nil {
+		t.Fatalf("unable to create change request: %s", err)
+	}
+	if cr.GetStatus() != api.ChangeRequestStatusRequested {
+		t.Fatalf("unexpected status %s", cr.GetStatus())
+	}
+
+	if _, _, err := client.ChangeRequests.Create(ctx, workspaceID, environmentID, req); !api.IsConflict(err) {
+		t.Fatalf("expected a conflict error for a second open change request, got: %v", err)
+	}
+
+	open, _, err := client.ChangeRequests.ListAll(ctx, &api.ChangeRequestListOpts{Status: api.ChangeRequestStatusRequested})
+	if err != nil || len(open) != 1 {
+		t.Fatalf("expected 1 open change request, got %d: %v", len(open), err)
+	}
+
+	if _, _, err := client.Splits.GetDefinition(ctx, workspaceID, "my-split", environmentID); !api.IsNotFound(err) {
+		t.Fatalf("expected the definition to await approval, got: %v", err)
+	}
+
+	if _, _, err := client.ChangeRequests.Approve(ctx, cr.GetID(), "looks good"); err != nil {
+		t.Fatalf("unable to approve change request: %s", err)
+	}
+
+	def, _, err := client.Splits.GetDefinition(ctx, workspaceID, "my-split", environmentID)
+	if err != nil || def.GetTrafficAllocation() != 50 {
+		t.Fatalf("expected the approved definition to be applied, got %+v: %v", def, err)
+	}
+
+	// Rejected and withdrawn change requests are not applied.
+	req.OperationType = api.ChangeRequestOperationKill
+	for _, status := range []string{api.ChangeRequestStatusRejected, api.ChangeRequestStatusWithdrawn} {
+		cr, _, err := client.ChangeRequests.Create(ctx, workspaceID, environmentID, req)
+		if err != nil {
+			t.Fatalf("unable to create change request: %s", err)
+		}
+
+		resolved, _, err := client.ChangeRequests.UpdateStatus(ctx, cr.GetID(), &api.ChangeRequestStatusRequest{Status: status})
+		if err != nil || resolved.GetStatus() != status {
+			t.Fatalf("unable to set change request status to %s: %v", status, err)
+		}
+	}
+
+	def, _, err = client.Splits.GetDefinition(ctx, workspaceID, "my-split", environmentID)
+	if err != nil || def.GetKilled() {
+		t.Fatalf("expected the definition not to be killed, got %+v: %v", def, err)
+	}
+
+	// Change requests are resolved upon submission when configured.
+	s.AutoResolveChangeRequests(api.ChangeRequestStatusApproved)
+	if _, _, err := client.ChangeRequests.Create(ctx, workspaceID, environmentID, req); err != nil {
+		t.Fatalf("unable to create change request: %s", err)
+	}
+
+	def, _, err = client.Splits.GetDefinition(ctx, workspaceID, "my-split", environmentID)
+	if err != nil || !def.GetKilled() {
+		t.Fatalf("expected the definition to be killed, got %+v: %v", def, err)
+	}
+
+	// Scheduled change requests are applied when published rather than when approved.
+	s.ScheduleChangeRequests(true)
+	req.OperationType = api.ChangeRequestOperationRestore
+	cr, _, err = client.ChangeRequests.Create(ctx, workspaceID, environmentID, req)
+	if err != nil || cr.GetStatus() != api.ChangeRequestStatusApproved {
+		t.Fatalf("expected an approved change request, got %+v: %v", cr, err)
+	}
+
+	def, _, err = client.Splits.GetDefinition(ctx, workspaceID, "my-split", environmentID)
+	if err != nil || !def.GetKilled() {
+		t.Fatalf("expected the definition to await publication, got %+v: %v", def, err)
+	}
+
+	if _, _, err := client.ChangeRequests.UpdateStatus(ctx, cr.GetID(), &api.ChangeRequestStatusRequest{Status: api.ChangeRequestStatusPublished}); err != nil {
+		t.Fatalf("unable to publish change request: %s", err)
+	}
+
+	def, _, err = client.Splits.GetDefinition(ctx, workspaceID, "my-split", environmentID)
+	if err != nil || def.GetKilled() {
+		t.Fatalf("expected the definition to be restored, got %+v: %v", def, err)
+	}
+}
+
+func TestServer_Tags(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	fixtures := s.Seed()
+	client := newTestClient(t, s)
+	ctx := context.Background()
+	workspaceID := fixtures.Workspace.GetID()
+
+	if _, _, err := client.Splits.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SplitCreateRequest{Name: "my-split"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+	if _, _, err := client.Segments.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SegmentRequest{Name: "beta"}); err != nil {
+		t.Fatalf("unable to create segment: %s", err)
+	}
+
+	if _, err := client.Splits.UpdateTags(ctx, workspaceID, "my-split", []string{"team-a", "cleanup"}); err != nil {
+		t.Fatalf("unable to tag split: %s", err)
+	}
+	if _, err := client.Segments.UpdateTags(ctx, workspaceID, "beta", []string{"team-a"}); err != nil {
+		t.Fatalf("unable to tag segment: %s", err)
+	}
+
+	split, _, err := client.Splits.Get(ctx, workspaceID, "my-split")
+	if err != nil || fmt.Sprint(split.TagNames()) != "[team-a cleanup]" {
+		t.Fatalf("unexpected split tags, got %+v: %v", split, err)
+	}
+
+	segment, _, err := client.Segments.Get(ctx, workspaceID, "beta")
+	if err != nil || fmt.Sprint(segment.TagNames()) != "[team-a]" {
+		t.Fatalf("unexpected segment tags, got %+v: %v", segment, err)
+	}
+
+	// Associating tags replaces the existing ones.
+	if _, err := client.Splits.UpdateTags(ctx, workspaceID, "my-split", nil); err != nil {
+		t.Fatalf("unable to untag split: %s", err)
+	}
+
+	split, _, err = client.Splits.Get(ctx, workspaceID, "my-split")
+	if err != nil || len(split.TagNames()) != 0 {
+		t.Fatalf("expected the split tags to be removed, got %+v: %v", split, err)
+	}
+
+	if _, err := client.Splits.UpdateTags(ctx, workspaceID, "missing", []string{"team-a"}); !api.IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,1865 @@
+package fakesplit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/davidji99/terraform-provider-split/api"
+)
+
+const (
+	// defaultListLimit is the page size of offset-based list endpoints when no limit is requested.
+	defaultListLimit = 20
+
+	// defaultKeysLimit is the page size of the segment keys endpoint when no limit is requested.
+	defaultKeysLimit = 100
+
+	// defaultMarkerLimit is the page size of marker-based list endpoints when no limit is requested.
+	defaultMarkerLimit = 50
+)
+
+// registerRoutes registers every supported endpoint. More specific routes must be registered first.
+func (s *Server) registerRoutes() {
+	// Workspaces
+	s.handle(http.MethodGet, "/workspaces", s.listWorkspaces)
+	s.handle(http.MethodPost, "/workspaces", s.createWorkspace)
+	s.handle(http.MethodPatch, "/workspaces/{ws}", s.updateWorkspace)
+	s.handle(http.MethodDelete, "/workspaces/{ws}", s.deleteWorkspace)
+
+	// Environments
+	s.handle(http.MethodGet, "/environments/ws/{ws}", s.listEnvironments)
+	s.handle(http.MethodPost, "/environments/ws/{ws}", s.createEnvironment)
+	s.handle(http.MethodPatch, "/environments/ws/{ws}/{env}", s.updateEnvironment)
+	s.handle(http.MethodDelete, "/environments/ws/{ws}/{env}", s.deleteEnvironment)
+
+	// Traffic types and attributes
+	s.handle(http.MethodGet, "/trafficTypes/ws/{ws}", s.listTrafficTypes)
+	s.handle(http.MethodPost, "/trafficTypes/ws/{ws}", s.createTrafficType)
+	s.handle(http.MethodDelete, "/trafficTypes/{tt}", s.deleteTrafficType)
+	s.handle(http.MethodGet, "/schema/ws/{ws}/trafficTypes/{tt}", s.listAttributes)
+	s.handle(http.MethodPost, "/schema/ws/{ws}/trafficTypes/{tt}", s.createAttribute)
+	s.handle(http.MethodPatch, "/schema/ws/{ws}/trafficTypes/{tt}/{attr}", s.updateAttribute)
+	s.handle(http.MethodDelete, "/schema/ws/{ws}/trafficTypes/{tt}/{attr}", s.deleteAttribute)
+
+	// Splits and split definitions
+	s.handle(http.MethodGet, "/splits/ws/{ws}", s.listSplits)
+	s.handle(http.MethodPost, "/splits/ws/{ws}/trafficTypes/{tt}", s.createSplit)
+	s.handle(http.MethodGet, "/splits/ws/{ws}/environments/{env}", s.listDefinitions)
+	s.handle(http.MethodPut, "/splits/ws/{ws}/{split}/updateDescription", s.updateSplitDescription)
+	s.handle(http.MethodPut, "/splits/ws/{ws}/{split}/addToFlagSets", s.addSplitToFlagSets)
+	s.handle(http.MethodPut, "/splits/ws/{ws}/{split}/removeFromFlagSets", s.removeSplitFromFlagSets)
+	s.handle(http.MethodGet, "/splits/ws/{ws}/{split}", s.getSplit)
+	s.handle(http.MethodDelete, "/splits/ws/{ws}/{split}", s.deleteSplit)
+	s.handle(http.MethodGet, "/splits/ws/{ws}/{split}/environments/{env}", s.getDefinition)
+	s.handle(http.MethodPost, "/splits/ws/{ws}/{split}/environments/{env}", s.createDefinition)
+	s.handle(http.MethodPut, "/splits/ws/{ws}/{split}/environments/{env}", s.updateDefinition)
+	s.handle(http.MethodPatch, "/splits/ws/{ws}/{split}/environments/{env}", s.patchDefinition)
+	s.handle(http.MethodDelete, "/splits/ws/{ws}/{split}/environments/{env}", s.deleteDefinition)
+	s.handle(http.MethodPut, "/splits/ws/{ws}/{split}/environments/{env}/kill", s.killDefinition)
+	s.handle(http.MethodPut, "/splits/ws/{ws}/{split}/environments/{env}/restore", s.restoreDefinition)
+
+	// Segments
+	s.handle(http.MethodGet, "/segments/ws/{ws}", s.listSegments)
+	s.handle(http.MethodPost, "/segments/ws/{ws}/trafficTypes/{tt}", s.createSegment)
+	s.handle(http.MethodGet, "/segments/ws/{ws}/environments/{env}", s.listEnvironmentSegments)
+	s.handle(http.MethodGet, "/segments/ws/{ws}/{segment}", s.getSegment)
+	s.handle(http.MethodDelete, "/segments/ws/{ws}/{segment}", s.deleteSegment)
+	s.handle(http.MethodPost, "/segments/{env}/{segment}", s.activateSegment)
+	s.handle(http.MethodDelete, "/segments/{env}/{segment}", s.deactivateSegment)
+	s.handle(http.MethodPut, "/segments/{env}/{segment}/uploadKeys", s.uploadSegmentKeys)
+	s.handle(http.MethodGet, "/segments/{env}/{segment}/keys", s.listSegmentKeys)
+	s.handle(http.MethodPut, "/segments/{env}/{segment}/removeKeys", s.removeSegmentKeys)
+
+	// Tags
+	s.handle(http.MethodPost, "/tags/ws/{ws}/object/{object}/objecttype/{type}", s.associateTags)
+
+	// Change requests
+	s.handle(http.MethodGet, "/changeRequests", s.listChangeRequests)
+	s.handle(http.MethodPost, "/changeRequests/ws/{ws}/environments/{env}", s.createChangeRequest)
+	s.handle(http.MethodGet, "/changeRequests/{cr}", s.getChangeRequest)
+	s.handle(http.MethodPut, "/changeRequests/{cr}", s.updateChangeRequest)
+
+	// Users, groups and API keys
+	s.handle(http.MethodGet, "/users", s.listUsers)
+	s.handle(http.MethodPost, "/users", s.inviteUser)
+	s.handle(http.MethodGet, "/users/{user}", s.getUser)
+	s.handle(http.MethodPut, "/users/{user}", s.updateUser)
+	s.handle(http.MethodPatch, "/users/{user}", s.updateUserGroups)
+	s.handle(http.MethodDelete, "/users/{user}", s.deletePendingUser)
+	s.handle(http.MethodGet, "/groups", s.listGroups)
+	s.handle(http.MethodPost, "/groups", s.createGroup)
+	s.handle(http.MethodGet, "/groups/{group}", s.getGroup)
+	s.handle(http.MethodPut, "/groups/{group}", s.updateGroup)
+	s.handle(http.MethodDelete, "/groups/{group}", s.deleteGroup)
+	s.handle(http.MethodPost, "/apiKeys", s.createAPIKey)
+	s.handle(http.MethodDelete, "/apiKeys/{key}", s.deleteAPIKey)
+
+	// Flag sets
+	s.handleV3(http.MethodGet, "/flag-sets", s.listFlagSets)
+	s.handleV3(http.MethodPost, "/flag-sets", s.createFlagSet)
+	s.handleV3(http.MethodGet, "/flag-sets/{id}", s.getFlagSet)
+	s.handleV3(http.MethodDelete, "/flag-sets/{id}", s.deleteFlagSet)
+}
+
+// patchOperation is a single JSON patch operation.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// page returns the bounds of the requested offset-based page of a list of total items.
+func (c *call) page(total, defaultLimit int) (start, end int, result api.GenericListResult) {
+	offset, _ := strconv.Atoi(c.query("offset"))
+	limit, _ := strconv.Atoi(c.query("limit"))
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	start = offset
+	if start > total {
+		start = total
+	}
+	end = start + limit
+	if end > total {
+		end = total
+	}
+
+	return start, end, api.GenericListResult{Offset: intPtr(offset), Limit: intPtr(limit), TotalCount: intPtr(total)}
+}
+
+// markerPage returns the bounds of the requested marker-based page of ids. The marker is the ID of
+// the last item of the previous page.
+func (c *call) markerPage(ids []string, markerParam, limitParam string) (start, end int, next *string) {
+	limit, _ := strconv.Atoi(c.query(limitParam))
+	if limit <= 0 {
+		limit = defaultMarkerLimit
+	}
+
+	if marker := c.query(markerParam); marker != "" {
+		for i, id := range ids {
+			if id == marker {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	end = start + limit
+	if end > len(ids) {
+		end = len(ids)
+	}
+
+	if end < len(ids) && end > start {
+		next = strPtr(ids[end-1])
+	}
+
+	return start, end, next
+}
+
+// now returns the current time in milliseconds.
+func now() int64 {
+	return time.Now().UnixMilli()
+}
+
+func (s *Server) workspace(id string) *api.Workspace {
+	for _, w := range s.workspaces {
+		if w.GetID() == id {
+			return w
+		}
+	}
+	return nil
+}
+
+func (s *Server) environment(workspaceID, id string) *api.Environment {
+	for _, e := range s.environments[workspaceID] {
+		if e.GetID() == id || e.GetName() == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// environmentByID looks up an environment across all workspaces.
+func (s *Server) environmentByID(id string) (string, *api.Environment) {
+	for workspaceID, envs := range s.environments {
+		for _, e := range envs {
+			if e.GetID() == id {
+				return workspaceID, e
+			}
+		}
+	}
+	return "", nil
+}
+
+func (s *Server) trafficType(id string) *api.TrafficType {
+	for _, t := range s.trafficTypes {
+		if t.GetID() == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) split(workspaceID, nameOrID string) *api.Split {
+	for _, sp := range s.splits[workspaceID] {
+		if sp.GetName() == nameOrID || sp.GetID() == nameOrID {
+			return sp
+		}
+	}
+	return nil
+}
+
+func (s *Server) flagSet(id string) *api.FlagSet {
+	for _, f := range s.flagSets {
+		if f.GetID() == id {
+			return f
+		}
+	}
+	return nil
+}
+
+func (s *Server) segment(workspaceID, name string) *api.Segment {
+	for _, seg := range s.segments[workspaceID] {
+		if seg.GetName() == name {
+			return seg
+		}
+	}
+	return nil
+}
+
+// segmentByEnvironment looks up a segment by name in the workspace of the given environment.
+func (s *Server) segmentByEnvironment(environmentID, name string) (*api.Environment, *api.Segment) {
+	workspaceID, env := s.environmentByID(environmentID)
+	if env == nil {
+		return nil, nil
+	}
+	return env, s.segment(workspaceID, name)
+}
+
+func (s *Server) user(id string) *api.User {
+	for _, u := range s.users {
+		if u.GetID() == id {
+			return u
+		}
+	}
+	return nil
+}
+
+func (s *Server) group(id string) *api.Group {
+	for _, g := range s.groups {
+		if g.GetID() == id {
+			return g
+		}
+	}
+	return nil
+}
+
+func definitionKey(workspaceID, environmentID, splitName string) string {
+	return workspaceID + "/" + environmentID + "/" + splitName
+}
+
+func segmentKey(environmentID, segmentName string) string {
+	return environmentID + "/" + segmentName
+}
+
+func trafficTypeRef(t *api.TrafficType) *api.TrafficType {
+	return &api.TrafficType{ID: t.ID, Name: t.Name}
+}
+
+func environmentRef(e *api.Environment) *api.Environment {
+	return &api.Environment{ID: e.ID, Name: e.Name}
+}
+
+func (s *Server) listWorkspaces(c *call) {
+	workspaces := make([]*api.Workspace, 0)
+	name := c.query("name")
+	for _, w := range s.workspaces {
+		if name != "" {
+			switch c.query("nameOp") {
+			case "STARTS_WITH":
+				if !strings.HasPrefix(w.GetName(), name) {
+					continue
+				}
+			case "CONTAINS":
+				if !strings.Contains(w.GetName(), name) {
+					continue
+				}
+			default:
+				if w.GetName() != name {
+					continue
+				}
+			}
+		}
+		workspaces = append(workspaces, w)
+	}
+
+	start, end, page := c.page(len(workspaces), defaultListLimit)
+	c.json(http.StatusOK, api.Workspaces{Objects: workspaces[start:end], GenericListResult: page})
+}
+
+func (s *Server) createWorkspace(c *call) {
+	var req api.WorkspaceRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	for _, w := range s.workspaces {
+		if w.GetName() == req.GetName() {
+			c.error(http.StatusConflict, "workspace %s already exists", req.GetName())
+			return
+		}
+	}
+
+	w := &api.Workspace{
+		ID:                       strPtr(newID()),
+		Name:                     strPtr(req.GetName()),
+		Type:                     strPtr("workspace"),
+		RequiresTitleAndComments: boolPtr(req.GetRequiresTitleAndComments()),
+	}
+	s.workspaces = append(s.workspaces, w)
+
+	c.json(http.StatusOK, w)
+}
+
+func (s *Server) updateWorkspace(c *call) {
+	w := s.workspace(c.param("ws"))
+	if w == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", c.param("ws"))
+		return
+	}
+
+	var ops []patchOperation
+	if !c.decode(&ops) {
+		return
+	}
+
+	for _, op := range ops {
+		switch op.Path {
+		case "/name":
+			if v, ok := op.Value.(string); ok {
+				w.Name = strPtr(v)
+			}
+		case "/requiresTitleAndComments":
+			if v, ok := op.Value.(bool); ok {
+				w.RequiresTitleAndComments = boolPtr(v)
+			}
+		}
+	}
+
+	c.json(http.StatusOK, w)
+}
+
+func (s *Server) deleteWorkspace(c *call) {
+	id := c.param("ws")
+	for i, w := range s.workspaces {
+		if w.GetID() == id {
+			s.workspaces = append(s.workspaces[:i], s.workspaces[i+1:]...)
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "workspace %s not found", id)
+}
+
+func (s *Server) listEnvironments(c *call) {
+	if s.workspace(c.param("ws")) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", c.param("ws"))
+		return
+	}
+
+	envs := s.environments[c.param("ws")]
+	if envs == nil {
+		envs = make([]*api.Environment, 0)
+	}
+	c.json(http.StatusOK, envs)
+}
+
+func (s *Server) createEnvironment(c *call) {
+	workspaceID := c.param("ws")
+	if s.workspace(workspaceID) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", workspaceID)
+		return
+	}
+
+	var req api.EnvironmentRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	if s.environment(workspaceID, req.GetName()) != nil {
+		c.error(http.StatusConflict, "environment %s already exists", req.GetName())
+		return
+	}
+
+	e := &api.Environment{
+		ID:         strPtr(newID()),
+		Name:       strPtr(req.GetName()),
+		Production: boolPtr(req.GetProduction()),
+	}
+	s.environments[workspaceID] = append(s.environments[workspaceID], e)
+
+	c.json(http.StatusOK, e)
+}
+
+func (s *Server) updateEnvironment(c *call) {
+	e := s.environment(c.param("ws"), c.param("env"))
+	if e == nil {
+		c.error(http.StatusNotFound, "environment %s not found", c.param("env"))
+		return
+	}
+
+	var ops []patchOperation
+	if !c.decode(&ops) {
+		return
+	}
+
+	for _, op := range ops {
+		v, _ := op.Value.(string)
+		switch op.Path {
+		case "/name":
+			e.Name = strPtr(v)
+		case "/production":
+			e.Production = boolPtr(v == "true")
+		}
+	}
+
+	c.json(http.StatusOK, e)
+}
+
+func (s *Server) deleteEnvironment(c *call) {
+	workspaceID := c.param("ws")
+	envs := s.environments[workspaceID]
+	for i, e := range envs {
+		if e.GetID() == c.param("env") {
+			s.environments[workspaceID] = append(envs[:i], envs[i+1:]...)
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "environment %s not found", c.param("env"))
+}
+
+func (s *Server) listTrafficTypes(c *call) {
+	if s.workspace(c.param("ws")) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", c.param("ws"))
+		return
+	}
+
+	trafficTypes := make([]*api.TrafficType, 0)
+	for _, t := range s.trafficTypes {
+		if t.GetWorkspace().GetID() == c.param("ws") {
+			trafficTypes = append(trafficTypes, t)
+		}
+	}
+	c.json(http.StatusOK, trafficTypes)
+}
+
+func (s *Server) createTrafficType(c *call) {
+	w := s.workspace(c.param("ws"))
+	if w == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", c.param("ws"))
+		return
+	}
+
+	var req api.TrafficTypeRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	for _, t := range s.trafficTypes {
+		if t.GetWorkspace().GetID() == w.GetID() && t.GetName() == req.Name {
+			c.error(http.StatusConflict, "traffic type %s already exists", req.Name)
+			return
+		}
+	}
+
+	t := &api.TrafficType{
+		ID:        strPtr(newID()),
+		Name:      strPtr(req.Name),
+		Type:      strPtr("traffic_type"),
+		Workspace: &api.Workspace{ID: w.ID, Name: w.Name, Type: w.Type},
+	}
+	s.trafficTypes = append(s.trafficTypes, t)
+
+	c.json(http.StatusOK, t)
+}
+
+func (s *Server) deleteTrafficType(c *call) {
+	for i, t := range s.trafficTypes {
+		if t.GetID() == c.param("tt") {
+			s.trafficTypes = append(s.trafficTypes[:i], s.trafficTypes[i+1:]...)
+			delete(s.attributes, t.GetID())
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "traffic type %s not found", c.param("tt"))
+}
+
+func (s *Server) listAttributes(c *call) {
+	if s.trafficType(c.param("tt")) == nil {
+		c.error(http.StatusNotFound, "traffic type %s not found", c.param("tt"))
+		return
+	}
+
+	attributes := make([]*api.Attribute, 0)
+	prefix := strings.ToLower(c.query("searchPrefix"))
+	for _, a := range s.attributes[c.param("tt")] {
+		if strings.HasPrefix(strings.ToLower(a.GetID()), prefix) {
+			attributes = append(attributes, a)
+		}
+	}
+
+	if c.query("paginate") != "true" {
+		c.json(http.StatusOK, attributes)
+		return
+	}
+
+	ids := make([]string, len(attributes))
+	for i, a := range attributes {
+		ids[i] = a.GetID()
+	}
+	start, end, next := c.markerPage(ids, "afterMarker", "markerLimit")
+
+	c.json(http.StatusOK, api.AttributeListResult{Objects: attributes[start:end], NextMarker: next})
+}
+
+func (s *Server) createAttribute(c *call) {
+	trafficTypeID := c.param("tt")
+	if s.trafficType(trafficTypeID) == nil {
+		c.error(http.StatusNotFound, "traffic type %s not found", trafficTypeID)
+		return
+	}
+
+	var req api.AttributeRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	for _, a := range s.attributes[trafficTypeID] {
+		if a.GetID() == req.GetIdentifier() {
+			c.error(http.StatusConflict, "attribute %s already exists", req.GetIdentifier())
+			return
+		}
+	}
+
+	a := &api.Attribute{
+		ID:              strPtr(req.GetIdentifier()),
+		OrganizationId:  strPtr(newID()),
+		TrafficTypeID:   strPtr(trafficTypeID),
+		DisplayName:     strPtr(req.GetDisplayName()),
+		Description:     strPtr(req.GetDescription()),
+		DataType:        req.DataType,
+		IsSearchable:    boolPtr(req.GetIsSearchable()),
+		SuggestedValues: req.SuggestedValues,
+	}
+	s.attributes[trafficTypeID] = append(s.attributes[trafficTypeID], a)
+
+	c.json(http.StatusOK, a)
+}
+
+func (s *Server) updateAttribute(c *call) {
+	for _, a := range s.attributes[c.param("tt")] {
+		if a.GetID() != c.param("attr") {
+			continue
+		}
+
+		var req api.AttributeRequest
+		if !c.decode(&req) {
+			return
+		}
+
+		if req.DisplayName != nil {
+			a.DisplayName = req.DisplayName
+		}
+		if req.Description != nil {
+			a.Description = req.Description
+		}
+		if req.DataType != nil {
+			a.DataType = req.DataType
+		}
+		if req.IsSearchable != nil {
+			a.IsSearchable = req.IsSearchable
+		}
+		if req.SuggestedValues != nil {
+			a.SuggestedValues = req.SuggestedValues
+		}
+
+		c.json(http.StatusOK, a)
+		return
+	}
+	c.error(http.StatusNotFound, "attribute %s not found", c.param("attr"))
+}
+
+func (s *Server) deleteAttribute(c *call) {
+	attributes := s.attributes[c.param("tt")]
+	for i, a := range attributes {
+		if a.GetID() == c.param("attr") {
+			s.attributes[c.param("tt")] = append(attributes[:i], attributes[i+1:]...)
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "attribute %s not found", c.param("attr"))
+}
+
+func (s *Server) listSplits(c *call) {
+	if s.workspace(c.param("ws")) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", c.param("ws"))
+		return
+	}
+
+	splits := make([]*api.Split, 0)
+	tag := c.query("tag")
+	for _, sp := range s.splits[c.param("ws")] {
+		if tag != "" && !hasSplitTag(sp, tag) {
+			continue
+		}
+		splits = append(splits, sp)
+	}
+
+	start, end, page := c.page(len(splits), defaultListLimit)
+	c.json(http.StatusOK, api.Splits{Objects: splits[start:end], GenericListResult: page})
+}
+
+func hasSplitTag(sp *api.Split, tag string) bool {
+	for _, t := range sp.Tags {
+		if t.Name == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createSplit(c *call) {
+	workspaceID := c.param("ws")
+	if s.workspace(workspaceID) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", workspaceID)
+		return
+	}
+
+	t := s.trafficType(c.param("tt"))
+	if t == nil {
+		c.error(http.StatusNotFound, "traffic type %s not found", c.param("tt"))
+		return
+	}
+
+	var req api.SplitCreateRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	if s.split(workspaceID, req.Name) != nil {
+		c.error(http.StatusConflict, "split %s already exists", req.Name)
+		return
+	}
+
+	created := now()
+	sp := &api.Split{
+		ID:                     strPtr(newID()),
+		Name:                   strPtr(req.Name),
+		Description:            strPtr(req.Description),
+		CreationTime:           &created,
+		RolloutStatusTimestamp: &created,
+		TrafficType:            trafficTypeRef(t),
+		RolloutStatus:          &api.SplitRolloutStatus{ID: strPtr(newID()), Name: strPtr("Pre-Production")},
+	}
+	s.splits[workspaceID] = append(s.splits[workspaceID], sp)
+
+	c.json(http.StatusOK, sp)
+}
+
+func (s *Server) getSplit(c *call) {
+	sp := s.split(c.param("ws"), c.param("split"))
+	if sp == nil {
+		c.error(http.StatusNotFound, "split %s not found", c.param("split"))
+		return
+	}
+	c.json(http.StatusOK, sp)
+}
+
+func (s *Server) updateSplitDescription(c *call) {
+	sp := s.split(c.param("ws"), c.param("split"))
+	if sp == nil {
+		c.error(http.StatusNotFound, "split %s not found", c.param("split"))
+		return
+	}
+
+	// The description is sent as the raw request body.
+	description := string(c.body)
+	if unquoted, err := strconv.Unquote(description); err == nil {
+		description = unquoted
+	}
+	sp.Description = strPtr(description)
+
+	c.json(http.StatusOK, sp)
+}
+
+func (s *Server) addSplitToFlagSets(c *call) {
+	workspaceID := c.param("ws")
+	sp := s.split(workspaceID, c.param("split"))
+	if sp == nil {
+		c.error(http.StatusNotFound, "split %s not found", c.param("split"))
+		return
+	}
+
+	var req api.SplitFlagSetsRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	for _, id := range req.IDs {
+		f := s.flagSet(id)
+		if f == nil || f.GetWorkspace().GetID() != workspaceID {
+			c.error(http.StatusNotFound, "flag set %s not found", id)
+			return
+		}
+	}
+
+	for _, id := range req.IDs {
+		if !slices.Contains(sp.FlagSetIDs(), id) {
+			sp.FlagSets = append(sp.FlagSets, &api.FlagSetIDRef{Type: strPtr("flag_set"), ID: strPtr(id)})
+		}
+	}
+
+	c.json(http.StatusOK, sp)
+}
+
+func (s *Server) removeSplitFromFlagSets(c *call) {
+	sp := s.split(c.param("ws"), c.param("split"))
+	if sp == nil {
+		c.error(http.StatusNotFound, "split %s not found", c.param("split"))
+		return
+	}
+
+	var req api.SplitFlagSetsRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	sp.FlagSets = slices.DeleteFunc(sp.FlagSets, func(f *api.FlagSetIDRef) bool {
+		return slices.Contains(req.IDs, f.GetID())
+	})
+
+	c.json(http.StatusOK, sp)
+}
+
+func (s *Server) deleteSplit(c *call) {
+	workspaceID := c.param("ws")
+	splits := s.splits[workspaceID]
+	for i, sp := range splits {
+		if sp.GetName() == c.param("split") {
+			s.splits[workspaceID] = append(splits[:i], splits[i+1:]...)
+
+			// Deleting a split removes its definitions from every environment.
+			for _, e := range s.environments[workspaceID] {
+				delete(s.definitions, definitionKey(workspaceID, e.GetID(), sp.GetName()))
+			}
+
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "split %s not found", c.param("split"))
+}
+
+func (s *Server) listDefinitions(c *call) {
+	workspaceID := c.param("ws")
+	if s.environment(workspaceID, c.param("env")) == nil {
+		c.error(http.StatusNotFound, "environment %s not found", c.param("env"))
+		return
+	}
+
+	definitions := make([]*api.SplitDefinition, 0)
+	for _, sp := range s.splits[workspaceID] {
+		if def, ok := s.definitions[definitionKey(workspaceID, c.param("env"), sp.GetName())]; ok {
+			definitions = append(definitions, def)
+		}
+	}
+
+	start, end, page := c.page(len(definitions), defaultListLimit)
+	c.json(http.StatusOK, api.SplitDefinitions{Objects: definitions[start:end], GenericListResult: page})
+}
+
+func (s *Server) getDefinition(c *call) {
+	def, ok := s.definitions[definitionKey(c.param("ws"), c.param("env"), c.param("split"))]
+	if !ok {
+		c.error(http.StatusNotFound, "split %s is not defined in environment %s", c.param("split"), c.param("env"))
+		return
+	}
+	c.json(http.StatusOK, def)
+}
+
+func (s *Server) createDefinition(c *call) {
+	s.saveDefinition(c, true)
+}
+
+func (s *Server) updateDefinition(c *call) {
+	s.saveDefinition(c, false)
+}
+
+// saveDefinition creates or fully replaces a split definition.
+func (s *Server) saveDefinition(c *call, create bool) {
+	workspaceID := c.param("ws")
+	sp := s.split(workspaceID, c.param("split"))
+	if sp == nil {
+		c.error(http.StatusNotFound, "split %s not found", c.param("split"))
+		return
+	}
+
+	env := s.environment(workspaceID, c.param("env"))
+	if env == nil {
+		c.error(http.StatusNotFound, "environment %s not found", c.param("env"))
+		return
+	}
+
+	key := definitionKey(workspaceID, env.GetID(), sp.GetName())
+	existing, exists := s.definitions[key]
+	if create && exists {
+		c.error(http.StatusConflict, "split %s is already defined in environment %s", sp.GetName(), env.GetName())
+		return
+	}
+	if !create && !exists {
+		c.error(http.StatusNotFound, "split %s is not defined in environment %s", sp.GetName(), env.GetName())
+		return
+	}
+
+	var req api.SplitDefinitionRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	if len(req.Treatments) < 2 {
+		c.error(http.StatusBadRequest, "a split definition requires at least two treatments")
+		return
+	}
+
+	c.json(http.StatusOK, s.storeDefinition(workspaceID, sp, env, existing, req))
+}
+
+// storeDefinition creates the split definition or replaces the existing one, keeping its ID and kill state.
+func (s *Server) storeDefinition(workspaceID string, sp *api.Split, env *api.Environment, existing *api.SplitDefinition, req api.SplitDefinitionRequest) *api.SplitDefinition {
+	def := &api.SplitDefinition{
+		ID:                strPtr(newID()),
+		Name:              sp.Name,
+		Environment:       environmentRef(env),
+		TrafficType:       sp.TrafficType,
+		Killed:            boolPtr(false),
+		DefaultTreatment:  strPtr(req.DefaultTreatment),
+		TrafficAllocation: intPtr(req.TrafficAllocation),
+		Treatments:        make([]*api.Treatment, 0),
+		Rules:             make([]*api.Rule, 0),
+		DefaultRule:       make([]*api.Bucket, 0),
+		CreationTIme:      intPtr(int(now() / 1000)),
+		LastUpdateTime:    intPtr(int(now() / 1000)),
+	}
+	if existing != nil {
+		def.ID = existing.ID
+		def.Killed = existing.Killed
+		def.CreationTIme = existing.CreationTIme
+	}
+
+	for i := range req.Treatments {
+		t := req.Treatments[i]
+		def.Treatments = append(def.Treatments, &t)
+	}
+	for i := range req.Rules {
+		r := req.Rules[i]
+		def.Rules = append(def.Rules, &r)
+	}
+	for i := range req.DefaultRule {
+		b := req.DefaultRule[i]
+		def.DefaultRule = append(def.DefaultRule, &b)
+	}
+
+	s.definitions[definitionKey(workspaceID, env.GetID(), sp.GetName())] = def
+
+	return def
+}
+
+// patchDefinition applies JSON patch operations replacing top-level fields of a split definition.
+func (s *Server) patchDefinition(c *call) {
+	def, ok := s.definitions[definitionKey(c.param("ws"), c.param("env"), c.param("split"))]
+	if !ok {
+		c.error(http.StatusNotFound, "split %s is not defined in environment %s", c.param("split"), c.param("env"))
+		return
+	}
+
+	var ops []struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+	if !c.decode(&ops) {
+		return
+	}
+
+	// Apply the operations to a copy so a failed patch leaves the definition untouched.
+	patched := *def
+	for _, op := range ops {
+		if op.Op != "replace" {
+			c.error(http.StatusBadRequest, "unsupported operation %s", op.Op)
+			return
+		}
+
+		var target interface{}
+		switch op.Path {
+		case "/treatments":
+			target = &patched.Treatments
+		case "/rules":
+			target = &patched.Rules
+		case "/defaultRule":
+			target = &patched.DefaultRule
+		case "/defaultTreatment":
+			target = &patched.DefaultTreatment
+		case "/trafficAllocation":
+			target = &patched.TrafficAllocation
+		default:
+			c.error(http.StatusBadRequest, "unsupported path %s", op.Path)
+			return
+		}
+
+		if err := json.Unmarshal(op.Value, target); err != nil {
+			c.error(http.StatusBadRequest, "invalid value for %s: %s", op.Path, err)
+			return
+		}
+	}
+
+	if len(patched.Treatments) < 2 {
+		c.error(http.StatusBadRequest, "a split definition requires at least two treatments")
+		return
+	}
+
+	patched.LastUpdateTime = intPtr(int(now() / 1000))
+	*def = patched
+
+	c.json(http.StatusOK, def)
+}
+
+func (s *Server) killDefinition(c *call) {
+	s.setKilled(c, true)
+}
+
+func (s *Server) restoreDefinition(c *call) {
+	s.setKilled(c, false)
+}
+
+// setKilled kills or restores a split definition.
+func (s *Server) setKilled(c *call, killed bool) {
+	def, ok := s.definitions[definitionKey(c.param("ws"), c.param("env"), c.param("split"))]
+	if !ok {
+		c.error(http.StatusNotFound, "split %s is not defined in environment %s", c.param("split"), c.param("env"))
+		return
+	}
+
+	def.Killed = boolPtr(killed)
+	def.LastUpdateTime = intPtr(int(now() / 1000))
+
+	c.json(http.StatusOK, def)
+}
+
+func (s *Server) deleteDefinition(c *call) {
+	key := definitionKey(c.param("ws"), c.param("env"), c.param("split"))
+	if _, ok := s.definitions[key]; !ok {
+		c.error(http.StatusNotFound, "split %s is not defined in environment %s", c.param("split"), c.param("env"))
+		return
+	}
+
+	delete(s.definitions, key)
+	c.json(http.StatusOK, true)
+}
+
+func (s *Server) listSegments(c *call) {
+	if s.workspace(c.param("ws")) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", c.param("ws"))
+		return
+	}
+
+	segments := s.segments[c.param("ws")]
+	if segments == nil {
+		segments = make([]*api.Segment, 0)
+	}
+
+	start, end, page := c.page(len(segments), defaultListLimit)
+	c.json(http.StatusOK, api.SegmentListResult{Objects: segments[start:end], GenericListResult: page})
+}
+
+func (s *Server) createSegment(c *call) {
+	workspaceID := c.param("ws")
+	if s.workspace(workspaceID) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", workspaceID)
+		return
+	}
+
+	t := s.trafficType(c.param("tt"))
+	if t == nil {
+		c.error(http.StatusNotFound, "traffic type %s not found", c.param("tt"))
+		return
+	}
+
+	var req api.SegmentRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	if s.segment(workspaceID, req.Name) != nil {
+		c.error(http.StatusConflict, "segment %s already exists", req.Name)
+		return
+	}
+
+	created := now()
+	seg := &api.Segment{
+		Name:         strPtr(req.Name),
+		Description:  strPtr(req.Description),
+		TrafficType:  trafficTypeRef(t),
+		CreationTime: &created,
+	}
+	s.segments[workspaceID] = append(s.segments[workspaceID], seg)
+
+	c.json(http.StatusOK, seg)
+}
+
+func (s *Server) getSegment(c *call) {
+	seg := s.segment(c.param("ws"), c.param("segment"))
+	if seg == nil {
+		c.error(http.StatusNotFound, "segment %s not found", c.param("segment"))
+		return
+	}
+	c.json(http.StatusOK, seg)
+}
+
+func (s *Server) deleteSegment(c *call) {
+	workspaceID := c.param("ws")
+	segments := s.segments[workspaceID]
+	for i, seg := range segments {
+		if seg.GetName() == c.param("segment") {
+			s.segments[workspaceID] = append(segments[:i], segments[i+1:]...)
+
+			for _, e := range s.environments[workspaceID] {
+				delete(s.activations, segmentKey(e.GetID(), seg.GetName()))
+				delete(s.segmentKeys, segmentKey(e.GetID(), seg.GetName()))
+			}
+
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "segment %s not found", c.param("segment"))
+}
+
+func (s *Server) listEnvironmentSegments(c *call) {
+	workspaceID := c.param("ws")
+	env := s.environment(workspaceID, c.param("env"))
+	if env == nil {
+		c.error(http.StatusNotFound, "environment %s not found", c.param("env"))
+		return
+	}
+
+	segments := make([]*api.Segment, 0)
+	for _, seg := range s.segments[workspaceID] {
+		if s.activations[segmentKey(env.GetID(), seg.GetName())] {
+			segments = append(segments, segmentInEnvironment(seg, env))
+		}
+	}
+
+	start, end, page := c.page(len(segments), defaultListLimit)
+	c.json(http.StatusOK, api.SegmentListResult{Objects: segments[start:end], GenericListResult: page})
+}
+
+// segmentInEnvironment returns a copy of seg as returned by environment specific endpoints.
+func segmentInEnvironment(seg *api.Segment, env *api.Environment) *api.Segment {
+	inEnv := *seg
+	inEnv.Environment = environmentRef(env)
+	return &inEnv
+}
+
+func (s *Server) activateSegment(c *call) {
+	env, seg := s.segmentByEnvironment(c.param("env"), c.param("segment"))
+	if env == nil || seg == nil {
+		c.error(http.StatusNotFound, "segment %s not found in environment %s", c.param("segment"), c.param("env"))
+		return
+	}
+
+	key := segmentKey(env.GetID(), seg.GetName())
+	if s.activations[key] {
+		c.error(http.StatusConflict, "segment %s is already active in environment %s", seg.GetName(), env.GetName())
+		return
+	}
+	s.activations[key] = true
+
+	c.json(http.StatusOK, segmentInEnvironment(seg, env))
+}
+
+func (s *Server) deactivateSegment(c *call) {
+	key := segmentKey(c.param("env"), c.param("segment"))
+	if !s.activations[key] {
+		c.error(http.StatusNotFound, "segment %s is not active in environment %s", c.param("segment"), c.param("env"))
+		return
+	}
+
+	delete(s.activations, key)
+	delete(s.segmentKeys, key)
+	c.json(http.StatusOK, true)
+}
+
+func (s *Server) uploadSegmentKeys(c *call) {
+	env, seg := s.segmentByEnvironment(c.param("env"), c.param("segment"))
+	key := segmentKey(c.param("env"), c.param("segment"))
+	if env == nil || seg == nil || !s.activations[key] {
+		c.error(http.StatusNotFound, "segment %s is not active in environment %s", c.param("segment"), c.param("env"))
+		return
+	}
+
+	var req api.EnvironmentSegmentKeysRequest
+	if !c.decode(&req) {
+		return
+	}
+	if len(req.Keys) > api.MaxSegmentKeysPerRequest {
+		c.error(http.StatusBadRequest, "at most %d keys can be sent in a single request", api.MaxSegmentKeysPerRequest)
+		return
+	}
+
+	keys := make(map[string]bool)
+	if c.query("replace") != "true" {
+		for _, k := range s.segmentKeys[key] {
+			keys[k] = true
+		}
+	}
+	for _, k := range req.Keys {
+		keys[k] = true
+	}
+	s.segmentKeys[key] = sortedKeys(keys)
+
+	updated := now()
+	c.json(http.StatusOK, api.EnvironmentSegment{
+		ID:             strPtr(newID()),
+		Environment:    env.ID,
+		Name:           seg.Name,
+		TrafficTypeID:  seg.GetTrafficType().ID,
+		Description:    seg.Description,
+		Status:         strPtr("ACTIVE"),
+		CreationTime:   seg.CreationTime,
+		LastUpdateTime: &updated,
+	})
+}
+
+func (s *Server) listSegmentKeys(c *call) {
+	key := segmentKey(c.param("env"), c.param("segment"))
+	if !s.activations[key] {
+		c.error(http.StatusNotFound, "segment %s is not active in environment %s", c.param("segment"), c.param("env"))
+		return
+	}
+
+	keys := s.segmentKeys[key]
+	start, end, page := c.page(len(keys), defaultKeysLimit)
+
+	result := api.SegmentKeysList{Keys: make([]*api.SegmentKey, 0), GenericListResult: page}
+	for _, k := range keys[start:end] {
+		result.Keys = append(result.Keys, &api.SegmentKey{Key: strPtr(k)})
+	}
+
+	c.json(http.StatusOK, result)
+}
+
+func (s *Server) removeSegmentKeys(c *call) {
+	key := segmentKey(c.param("env"), c.param("segment"))
+	if !s.activations[key] {
+		c.error(http.StatusNotFound, "segment %s is not active in environment %s", c.param("segment"), c.param("env"))
+		return
+	}
+
+	var req api.EnvironmentSegmentKeysRequest
+	if !c.decode(&req) {
+		return
+	}
+	if len(req.Keys) > api.MaxSegmentKeysPerRequest {
+		c.error(http.StatusBadRequest, "at most %d keys can be sent in a single request", api.MaxSegmentKeysPerRequest)
+		return
+	}
+
+	keys := make(map[string]bool)
+	for _, k := range s.segmentKeys[key] {
+		keys[k] = true
+	}
+	for _, k := range req.Keys {
+		delete(keys, k)
+	}
+	s.segmentKeys[key] = sortedKeys(keys)
+
+	c.json(http.StatusOK, true)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// changeRequest is a change request along with the workspace and environment the change applies to.
+type changeRequest struct {
+	*api.ChangeRequest
+	workspaceID   string
+	environmentID string
+}
+
+func (s *Server) changeRequest(id string) *changeRequest {
+	for _, cr := range s.changeRequests {
+		if cr.GetID() == id {
+			return cr
+		}
+	}
+	return nil
+}
+
+func (s *Server) associateTags(c *call) {
+	var tags []string
+	if !c.decode(&tags) {
+		return
+	}
+
+	workspaceID, object := c.param("ws"), c.param("object")
+	switch c.param("type") {
+	case api.TagObjectTypeSplit:
+		sp := s.split(workspaceID, object)
+		if sp == nil {
+			c.error(http.StatusNotFound, "split %s not found", object)
+			return
+		}
+		sp.Tags = make([]api.SplitTag, 0, len(tags))
+		for _, t := range tags {
+			sp.Tags = append(sp.Tags, api.SplitTag{Name: t})
+		}
+	case api.TagObjectTypeSegment:
+		seg := s.segment(workspaceID, object)
+		if seg == nil {
+			c.error(http.StatusNotFound, "segment %s not found", object)
+			return
+		}
+		seg.Tags = make([]*api.Tag, 0, len(tags))
+		for _, t := range tags {
+			seg.Tags = append(seg.Tags, &api.Tag{Name: strPtr(t)})
+		}
+	default:
+		c.error(http.StatusBadRequest, "unsupported object type %s", c.param("type"))
+		return
+	}
+
+	c.json(http.StatusOK, tags)
+}
+
+func (s *Server) listChangeRequests(c *call) {
+	changeRequests := make([]*api.ChangeRequest, 0)
+	ids := make([]string, 0)
+	for _, cr := range s.changeRequests {
+		if status := c.query("status"); status != "" && cr.GetStatus() != status {
+			continue
+		}
+		if environmentID := c.query("environmentId"); environmentID != "" && cr.environmentID != environmentID {
+			continue
+		}
+		changeRequests = append(changeRequests, cr.ChangeRequest)
+		ids = append(ids, cr.GetID())
+	}
+
+	start, end, next := c.markerPage(ids, "after", "limit")
+
+	c.json(http.StatusOK, api.ChangeRequestListResult{
+		Data:       changeRequests[start:end],
+		NextMarker: next,
+		Limit:      intPtr(end - start),
+		Count:      intPtr(end - start),
+	})
+}
+
+func (s *Server) createChangeRequest(c *call) {
+	workspaceID := c.param("ws")
+	env := s.environment(workspaceID, c.param("env"))
+	if env == nil {
+		c.error(http.StatusNotFound, "environment %s not found", c.param("env"))
+		return
+	}
+
+	var req api.ChangeRequestRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	var subject string
+	switch {
+	case req.Split != nil && req.Segment == nil:
+		sp := s.split(workspaceID, req.Split.Name)
+		if sp == nil {
+			c.error(http.StatusNotFound, "split %s not found", req.Split.Name)
+			return
+		}
+
+		_, defined := s.definitions[definitionKey(workspaceID, env.GetID(), sp.GetName())]
+		if req.OperationType == api.ChangeRequestOperationCreate && defined {
+			c.error(http.StatusConflict, "split %s is already defined in environment %s", sp.GetName(), env.GetName())
+			return
+		}
+		if req.OperationType != api.ChangeRequestOperationCreate && !defined {
+			c.error(http.StatusNotFound, "split %s is not defined in environment %s", sp.GetName(), env.GetName())
+			return
+		}
+		subject = "split/" + sp.GetName()
+	case req.Segment != nil && req.Split == nil:
+		if !s.activations[segmentKey(env.GetID(), req.Segment.Name)] {
+			c.error(http.StatusNotFound, "segment %s is not active in environment %s", req.Segment.Name, env.GetName())
+			return
+		}
+		subject = "segment/" + req.Segment.Name
+	default:
+		c.error(http.StatusBadRequest, "a change request requires either a split or a segment")
+		return
+	}
+
+	// Only one open change request is allowed per split or segment and environment.
+	for _, cr := range s.changeRequests {
+		if cr.environmentID == env.GetID() && cr.GetStatus() == api.ChangeRequestStatusRequested && changeRequestSubject(cr) == subject {
+			c.error(http.StatusConflict, "%s already has an open change request %s", subject, cr.GetID())
+			return
+		}
+	}
+
+	cr := &changeRequest{
+		ChangeRequest: &api.ChangeRequest{
+			ID:            strPtr(newID()),
+			Status:        strPtr(api.ChangeRequestStatusRequested),
+			Title:         strPtr(req.Title),
+			Comment:       strPtr(req.Comment),
+			OperationType: strPtr(req.OperationType),
+			Split:         req.Split,
+			Segment:       req.Segment,
+			Approvers:     req.Approvers,
+			Comments:      make([]*api.ChangeRequestComment, 0),
+		},
+		workspaceID:   workspaceID,
+		environmentID: env.GetID(),
+	}
+	if cr.Approvers == nil {
+		cr.Approvers = make([]string, 0)
+	}
+	addChangeRequestComment(cr, req.Comment, "SUBMITTER")
+	s.changeRequests = append(s.changeRequests, cr)
+
+	if s.changeRequestResolution != "" {
+		if err := s.resolveChangeRequest(cr, s.changeRequestResolution, ""); err != nil {
+			c.error(http.StatusBadRequest, "%s", err)
+			return
+		}
+	}
+
+	c.json(http.StatusOK, cr.ChangeRequest)
+}
+
+func (s *Server) getChangeRequest(c *call) {
+	cr := s.changeRequest(c.param("cr"))
+	if cr == nil {
+		c.error(http.StatusNotFound, "change request %s not found", c.param("cr"))
+		return
+	}
+	c.json(http.StatusOK, cr.ChangeRequest)
+}
+
+func (s *Server) updateChangeRequest(c *call) {
+	cr := s.changeRequest(c.param("cr"))
+	if cr == nil {
+		c.error(http.StatusNotFound, "change request %s not found", c.param("cr"))
+		return
+	}
+
+	var req api.ChangeRequestStatusRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	// Approved change requests that are not published yet can only be published.
+	open := cr.GetStatus() == api.ChangeRequestStatusRequested ||
+		(cr.GetStatus() == api.ChangeRequestStatusApproved && req.Status == api.ChangeRequestStatusPublished)
+	if !open {
+		c.error(http.StatusBadRequest, "change request %s is %s", cr.GetID(), cr.GetStatus())
+		return
+	}
+
+	if err := s.resolveChangeRequest(cr, req.Status, req.Comment); err != nil {
+		c.error(http.StatusBadRequest, "%s", err)
+		return
+	}
+
+	c.json(http.StatusOK, cr.ChangeRequest)
+}
+
+// resolveChangeRequest sets the status of an open change request, applying the change when it is approved, or
+// when it is published if change requests are scheduled.
+func (s *Server) resolveChangeRequest(cr *changeRequest, status, comment string) error {
+	role := "APPROVER"
+	switch status {
+	case api.ChangeRequestStatusApproved:
+		if s.changeRequestsScheduled {
+			break
+		}
+		if err := s.applyChangeRequest(cr); err != nil {
+			return err
+		}
+	case api.ChangeRequestStatusPublished:
+		if err := s.applyChangeRequest(cr); err != nil {
+			return err
+		}
+	case api.ChangeRequestStatusRejected:
+	case api.ChangeRequestStatusWithdrawn:
+		role = "SUBMITTER"
+	default:
+		return fmt.Errorf("unsupported change request status %s", status)
+	}
+
+	cr.Status = strPtr(status)
+	addChangeRequestComment(cr, comment, role)
+
+	return nil
+}
+
+// applyChangeRequest makes the change proposed by an approved change request.
+func (s *Server) applyChangeRequest(cr *changeRequest) error {
+	if cr.Segment != nil {
+		keys := make(map[string]bool)
+		for _, k := range cr.Segment.Keys {
+			keys[k] = true
+		}
+		s.segmentKeys[segmentKey(cr.environmentID, cr.Segment.Name)] = sortedKeys(keys)
+		return nil
+	}
+
+	sp := s.split(cr.workspaceID, cr.Split.Name)
+	env := s.environment(cr.workspaceID, cr.environmentID)
+	if sp == nil || env == nil {
+		return fmt.Errorf("split %s no longer exists", cr.Split.Name)
+	}
+
+	key := definitionKey(cr.workspaceID, env.GetID(), sp.GetName())
+	existing := s.definitions[key]
+
+	switch cr.GetOperationType() {
+	case api.ChangeRequestOperationCreate, api.ChangeRequestOperationUpdate:
+		if len(cr.Split.Treatments) < 2 {
+			return fmt.Errorf("a split definition requires at least two treatments")
+		}
+
+		req := api.SplitDefinitionRequest{
+			Treatments:        cr.Split.Treatments,
+			Rules:             cr.Split.Rules,
+			DefaultRule:       cr.Split.DefaultRule,
+			DefaultTreatment:  cr.Split.DefaultTreatment,
+			TrafficAllocation: 100,
+		}
+		if cr.Split.TrafficAllocation != nil {
+			req.TrafficAllocation = *cr.Split.TrafficAllocation
+		}
+		s.storeDefinition(cr.workspaceID, sp, env, existing, req)
+	case api.ChangeRequestOperationKill, api.ChangeRequestOperationRestore:
+		if existing == nil {
+			return fmt.Errorf("split %s is not defined in environment %s", sp.GetName(), env.GetName())
+		}
+		existing.Killed = boolPtr(cr.GetOperationType() == api.ChangeRequestOperationKill)
+		existing.LastUpdateTime = intPtr(int(now() / 1000))
+	case api.ChangeRequestOperationArchive:
+		delete(s.definitions, key)
+	default:
+		return fmt.Errorf("unsupported operation %s", cr.GetOperationType())
+	}
+
+	return nil
+}
+
+func changeRequestSubject(cr *changeRequest) string {
+	if cr.Segment != nil {
+		return "segment/" + cr.Segment.Name
+	}
+	return "split/" + cr.Split.Name
+}
+
+func addChangeRequestComment(cr *changeRequest, comment, role string) {
+	if comment == "" {
+		return
+	}
+	timestamp := now()
+	cr.Comments = append(cr.Comments, &api.ChangeRequestComment{
+		Comment:   strPtr(comment),
+		Role:      strPtr(role),
+		Timestamp: &timestamp,
+	})
+}
+
+func (s *Server) listUsers(c *call) {
+	// Like the Split API, only active users are listed unless another status is requested.
+	status := c.query("status")
+	if status == "" {
+		status = api.UserStatusActive
+	}
+
+	users := make([]*api.User, 0)
+	for _, u := range s.users {
+		if u.GetStatus() != status {
+			continue
+		}
+		if groupID := c.query("group_id"); groupID != "" && !inGroup(u, groupID) {
+			continue
+		}
+		users = append(users, u)
+	}
+
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.GetID()
+	}
+	start, end, next := c.markerPage(ids, "after", "limit")
+
+	c.json(http.StatusOK, api.UserListResult{
+		Data:       users[start:end],
+		NextMarker: next,
+		Limit:      intPtr(end - start),
+		Count:      intPtr(end - start),
+	})
+}
+
+func inGroup(u *api.User, groupID string) bool {
+	for _, g := range u.Groups {
+		if g.GetID() == groupID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) inviteUser(c *call) {
+	var req api.UserCreateRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	for _, u := range s.users {
+		if strings.EqualFold(u.GetEmail(), req.Email) {
+			c.error(http.StatusConflict, "user %s already exists", req.Email)
+			return
+		}
+	}
+
+	u := &api.User{
+		ID:     strPtr(newID()),
+		Type:   strPtr("user"),
+		Email:  strPtr(req.Email),
+		Status: strPtr(api.UserStatusPending),
+		TFA:    boolPtr(false),
+	}
+	for _, ref := range req.Groups {
+		g := s.group(ref.ID)
+		if g == nil {
+			c.error(http.StatusBadRequest, "group %s not found", ref.ID)
+			return
+		}
+		u.Groups = append(u.Groups, g)
+	}
+	s.users = append(s.users, u)
+
+	c.json(http.StatusOK, u)
+}
+
+func (s *Server) getUser(c *call) {
+	u := s.user(c.param("user"))
+	if u == nil {
+		c.error(http.StatusNotFound, "user %s not found", c.param("user"))
+		return
+	}
+	c.json(http.StatusOK, u)
+}
+
+func (s *Server) updateUser(c *call) {
+	u := s.user(c.param("user"))
+	if u == nil {
+		c.error(http.StatusNotFound, "user %s not found", c.param("user"))
+		return
+	}
+
+	var req api.UserUpdateRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	if req.Name != "" {
+		u.Name = strPtr(req.Name)
+	}
+	if req.Email != "" {
+		u.Email = strPtr(req.Email)
+	}
+	if req.TFA != nil {
+		u.TFA = req.TFA
+	}
+	if req.Status != "" {
+		u.Status = strPtr(req.Status)
+	}
+
+	c.json(http.StatusOK, u)
+}
+
+func (s *Server) updateUserGroups(c *call) {
+	u := s.user(c.param("user"))
+	if u == nil {
+		c.error(http.StatusNotFound, "user %s not found", c.param("user"))
+		return
+	}
+
+	var ops []api.UserGroupsPatch
+	if !c.decode(&ops) {
+		return
+	}
+
+	groups := u.Groups
+	for _, op := range ops {
+		if op.Op != "replace" || op.Path != "/groups" {
+			c.error(http.StatusBadRequest, "unsupported patch operation %s %s", op.Op, op.Path)
+			return
+		}
+
+		groups = make([]*api.Group, 0, len(op.Value))
+		for _, ref := range op.Value {
+			g := s.group(ref.ID)
+			if g == nil {
+				c.error(http.StatusBadRequest, "group %s not found", ref.ID)
+				return
+			}
+			groups = append(groups, g)
+		}
+	}
+	u.Groups = groups
+
+	c.json(http.StatusOK, u)
+}
+
+func (s *Server) deletePendingUser(c *call) {
+	for i, u := range s.users {
+		if u.GetID() != c.param("user") {
+			continue
+		}
+
+		if u.GetStatus() != api.UserStatusPending {
+			c.error(http.StatusBadRequest, "only pending users can be deleted")
+			return
+		}
+
+		s.users = append(s.users[:i], s.users[i+1:]...)
+		c.json(http.StatusOK, true)
+		return
+	}
+	c.error(http.StatusNotFound, "user %s not found", c.param("user"))
+}
+
+func (s *Server) listGroups(c *call) {
+	groups := s.groups
+	if groups == nil {
+		groups = make([]*api.Group, 0)
+	}
+
+	c.json(http.StatusOK, api.GroupListResult{Data: groups, Limit: intPtr(len(groups)), Count: intPtr(len(groups))})
+}
+
+func (s *Server) createGroup(c *call) {
+	var req api.GroupRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	for _, g := range s.groups {
+		if g.GetName() == req.Name {
+			c.error(http.StatusConflict, "group %s already exists", req.Name)
+			return
+		}
+	}
+
+	g := &api.Group{
+		ID:          strPtr(newID()),
+		Name:        strPtr(req.Name),
+		Description: strPtr(req.Description),
+		Type:        strPtr("group"),
+	}
+	s.groups = append(s.groups, g)
+
+	c.json(http.StatusOK, g)
+}
+
+func (s *Server) getGroup(c *call) {
+	g := s.group(c.param("group"))
+	if g == nil {
+		c.error(http.StatusNotFound, "group %s not found", c.param("group"))
+		return
+	}
+	c.json(http.StatusOK, g)
+}
+
+func (s *Server) updateGroup(c *call) {
+	g := s.group(c.param("group"))
+	if g == nil {
+		c.error(http.StatusNotFound, "group %s not found", c.param("group"))
+		return
+	}
+
+	var req api.GroupRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	g.Name = strPtr(req.Name)
+	g.Description = strPtr(req.Description)
+
+	c.json(http.StatusOK, g)
+}
+
+func (s *Server) deleteGroup(c *call) {
+	for i, g := range s.groups {
+		if g.GetID() == c.param("group") {
+			s.groups = append(s.groups[:i], s.groups[i+1:]...)
+
+			for _, u := range s.users {
+				for j, ug := range u.Groups {
+					if ug.GetID() == g.GetID() {
+						u.Groups = append(u.Groups[:j], u.Groups[j+1:]...)
+						break
+					}
+				}
+			}
+
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "group %s not found", c.param("group"))
+}
+
+func (s *Server) createAPIKey(c *call) {
+	var req api.KeyRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	for _, e := range req.Environments {
+		if _, env := s.environmentByID(e.Id); env == nil {
+			c.error(http.StatusBadRequest, "environment %s not found", e.Id)
+			return
+		}
+	}
+
+	k := &api.KeyResponse{
+		Id:         strPtr(newID()),
+		Name:       strPtr(req.Name),
+		Roles:      req.Roles,
+		Type:       strPtr("api_key"),
+		ApiKeyType: strPtr(req.KeyType),
+		Key:        strPtr(strings.ReplaceAll(newID(), "-", "")),
+	}
+	s.apiKeys = append(s.apiKeys, k)
+
+	c.json(http.StatusOK, k)
+}
+
+func (s *Server) deleteAPIKey(c *call) {
+	for i, k := range s.apiKeys {
+		if k.GetKey() == c.param("key") || k.GetId() == c.param("key") {
+			s.apiKeys = append(s.apiKeys[:i], s.apiKeys[i+1:]...)
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "api key %s not found", c.param("key"))
+}
+
+func (s *Server) listFlagSets(c *call) {
+	workspaceID := c.query("workspace_id")
+	if s.workspace(workspaceID) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", workspaceID)
+		return
+	}
+
+	flagSets := make([]*api.FlagSet, 0)
+	for _, f := range s.flagSets {
+		if f.GetWorkspace().GetID() == workspaceID {
+			flagSets = append(flagSets, f)
+		}
+	}
+
+	ids := make([]string, len(flagSets))
+	for i, f := range flagSets {
+		ids[i] = f.GetID()
+	}
+	start, end, next := c.markerPage(ids, "after", "limit")
+
+	c.json(http.StatusOK, api.FlagSetListResult{Objects: flagSets[start:end], NextMarker: next})
+}
+
+func (s *Server) createFlagSet(c *call) {
+	var req api.FlagSetRequest
+	if !c.decode(&req) {
+		return
+	}
+
+	workspaceID := req.GetWorkspace().GetID()
+	if s.workspace(workspaceID) == nil {
+		c.error(http.StatusNotFound, "workspace %s not found", workspaceID)
+		return
+	}
+
+	for _, f := range s.flagSets {
+		if f.GetWorkspace().GetID() == workspaceID && f.GetName() == req.GetName() {
+			c.error(http.StatusConflict, "flag set %s already exists", req.GetName())
+			return
+		}
+	}
+
+	f := &api.FlagSet{
+		ID:          strPtr(newID()),
+		Name:        strPtr(req.GetName()),
+		Description: strPtr(req.GetDescription()),
+		Workspace:   &api.WorkspaceIDRef{Type: strPtr("workspace"), ID: strPtr(workspaceID)},
+	}
+	s.flagSets = append(s.flagSets, f)
+
+	c.json(http.StatusOK, f)
+}
+
+func (s *Server) getFlagSet(c *call) {
+	if f := s.flagSet(c.param("id")); f != nil {
+		c.json(http.StatusOK, f)
+		return
+	}
+	c.error(http.StatusNotFound, "flag set %s not found", c.param("id"))
+}
+
+func (s *Server) deleteFlagSet(c *call) {
+	for i, f := range s.flagSets {
+		if f.GetID() == c.param("id") {
+			s.flagSets = append(s.flagSets[:i], s.flagSets[i+1:]...)
+
+			// Deleting a flag set removes the splits it contains from it.
+			for _, sp := range s.splits[f.GetWorkspace().GetID()] {
+				sp.FlagSets = slices.DeleteFunc(sp.FlagSets, func(ref *api.FlagSetIDRef) bool {
+					return ref.GetID() == f.GetID()
+				})
+			}
+
+			c.json(http.StatusOK, true)
+			return
+		}
+	}
+	c.error(http.StatusNotFound, "flag set %s not found", c.param("id"))
+}
@@ -0,0 +1,90 @@
+package fakesplit
+
+import (
+	"github.com/davidji99/terraform-provider-split/api"
+)
+
+// Fixtures are the resources created by Seed. Acceptance tests expect them to exist beforehand.
+type Fixtures struct {
+	Workspace   *api.Workspace
+	Environment *api.Environment
+	TrafficType *api.TrafficType
+	User        *api.User
+}
+
+// Seed creates a workspace with an environment and a traffic type, as well as an active user.
+func (s *Server) Seed() *Fixtures {
+	w := s.AddWorkspace("Default")
+
+	return &Fixtures{
+		Workspace:   w,
+		Environment: s.AddEnvironment(w.GetID(), "Production", true),
+		TrafficType: s.AddTrafficType(w.GetID(), "user"),
+		User:        s.AddUser("fake-user@example.com", api.UserStatusActive),
+	}
+}
+
+// AddWorkspace creates a workspace.
+func (s *Server) AddWorkspace(name string) *api.Workspace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := &api.Workspace{
+		ID:                       strPtr(newID()),
+		Name:                     strPtr(name),
+		Type:                     strPtr("workspace"),
+		RequiresTitleAndComments: boolPtr(false),
+	}
+	s.workspaces = append(s.workspaces, w)
+
+	return w
+}
+
+// AddEnvironment creates an environment in a workspace.
+func (s *Server) AddEnvironment(workspaceID, name string, production bool) *api.Environment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &api.Environment{
+		ID:         strPtr(newID()),
+		Name:       strPtr(name),
+		Production: boolPtr(production),
+	}
+	s.environments[workspaceID] = append(s.environments[workspaceID], e)
+
+	return e
+}
+
+// AddTrafficType creates a traffic type in a workspace.
+func (s *Server) AddTrafficType(workspaceID, name string) *api.TrafficType {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &api.TrafficType{
+		ID:        strPtr(newID()),
+		Name:      strPtr(name),
+		Type:      strPtr("traffic_type"),
+		Workspace: &api.Workspace{ID: strPtr(workspaceID), Type: strPtr("workspace")},
+	}
+	s.trafficTypes = append(s.trafficTypes, t)
+
+	return t
+}
+
+// AddUser creates a user with the given status.
+func (s *Server) AddUser(email, status string) *api.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u := &api.User{
+		ID:     strPtr(newID()),
+		Type:   strPtr("user"),
+		Name:   strPtr(email),
+		Email:  strPtr(email),
+		Status: strPtr(status),
+		TFA:    boolPtr(false),
+	}
+	s.users = append(s.users, u)
+
+	return u
+}
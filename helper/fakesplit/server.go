@@ -0,0 +1,392 @@
+// Package fakesplit provides an in-memory implementation of the Split Admin API for testing.
+//
+// The fake server implements enough of the APIv2 and APIv3 endpoints used by the provider to run the
+// acceptance tests without a real Split organization. It also supports injecting error responses,
+// such as 404, 409 or 429, to exercise error handling and retries.
+package fakesplit
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/davidji99/terraform-provider-split/api"
+)
+
+const (
+	// APIv2Path is the path under which the APIv2 endpoints are served.
+	APIv2Path = "/internal/api/v2"
+
+	// APIv3Path is the path under which the APIv3 endpoints are served.
+	APIv3Path = "/api/v3"
+)
+
+// Server is an in-memory Split Admin API backed by an httptest.Server.
+//
+// Every request is handled while holding the server lock, so handlers and lookups may access the state directly.
+type Server struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	v2Routes  []route
+	v3Routes  []route
+	faults    []*Fault
+	requests  []Request
+	requestID int
+
+	workspaces   []*api.Workspace
+	environments map[string][]*api.Environment
+	trafficTypes []*api.TrafficType
+	attributes   map[string][]*api.Attribute
+	splits       map[string][]*api.Split
+	definitions  map[string]*api.SplitDefinition
+	segments     map[string][]*api.Segment
+	activations  map[string]bool
+	segmentKeys  map[string][]string
+	flagSets     []*api.FlagSet
+	users        []*api.User
+	groups       []*api.Group
+	apiKeys      []*api.KeyResponse
+
+	changeRequests          []*changeRequest
+	changeRequestResolution string
+	changeRequestsScheduled bool
+}
+
+// Request is a request received by the fake server.
+type Request struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Path is the request path relative to the API version prefix, such as /splits/ws/{id}.
+	Path string
+
+	// Query is the raw query string of the request.
+	Query string
+
+	// Body is the raw request body.
+	Body string
+}
+
+// Fault is an error response returned by the fake server instead of handling a matching request.
+type Fault struct {
+	// Method is the HTTP method to match. An empty method matches every method.
+	Method string
+
+	// PathPrefix is matched against the request path relative to the API version prefix, such as /splits/ws.
+	// An empty prefix matches every path.
+	PathPrefix string
+
+	// StatusCode is the status code of the error response.
+	StatusCode int
+
+	// Times is the number of requests the fault applies to. Zero or less applies the fault to every request.
+	Times int
+
+	// Header is added to the error response.
+	Header map[string]string
+
+	// Message is the error message in the response body.
+	Message string
+}
+
+// New starts a new fake server. Callers should call Close when finished.
+func New() *Server {
+	s := &Server{
+		environments: make(map[string][]*api.Environment),
+		attributes:   make(map[string][]*api.Attribute),
+		splits:       make(map[string][]*api.Split),
+		definitions:  make(map[string]*api.SplitDefinition),
+		segments:     make(map[string][]*api.Segment),
+		activations:  make(map[string]bool),
+		segmentKeys:  make(map[string][]string),
+	}
+
+	s.registerRoutes()
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// AutoResolveChangeRequests resolves every change request submitted afterwards with the given status, such as
+// api.ChangeRequestStatusApproved. An empty status leaves change requests open until their status is updated.
+func (s *Server) AutoResolveChangeRequests(status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.changeRequestResolution = status
+}
+
+// ScheduleChangeRequests makes approved change requests wait, like scheduled change requests, until their status
+// is updated to api.ChangeRequestStatusPublished before the change is applied.
+func (s *Server) ScheduleChangeRequests(scheduled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.changeRequestsScheduled = scheduled
+}
+
+// Close shuts down the fake server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the base URL of the fake server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// APIBaseURL returns the APIv2 base URL, suitable for the provider's base_url.
+func (s *Server) APIBaseURL() string {
+	return s.server.URL + APIv2Path
+}
+
+// APIv3BaseURL returns the APIv3 base URL, suitable for the provider's base_url_v3.
+func (s *Server) APIv3BaseURL() string {
+	return s.server.URL + APIv3Path
+}
+
+// InjectFault registers a fault. Faults are matched in the order they are registered.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fault := f
+	s.faults = append(s.faults, &fault)
+}
+
+// InjectNotFound makes the next times requests matching method and pathPrefix return a 404.
+func (s *Server) InjectNotFound(method, pathPrefix string, times int) {
+	s.InjectFault(Fault{Method: method, PathPrefix: pathPrefix, StatusCode: http.StatusNotFound, Times: times})
+}
+
+// InjectConflict makes the next times requests matching method and pathPrefix return a 409.
+func (s *Server) InjectConflict(method, pathPrefix string, times int) {
+	s.InjectFault(Fault{Method: method, PathPrefix: pathPrefix, StatusCode: http.StatusConflict, Times: times})
+}
+
+// InjectRateLimit makes the next times requests matching method and pathPrefix return a 429
+// with the organization rate limit resetting after resetSeconds.
+func (s *Server) InjectRateLimit(method, pathPrefix string, times, resetSeconds int) {
+	s.InjectFault(Fault{
+		Method:     method,
+		PathPrefix: pathPrefix,
+		StatusCode: http.StatusTooManyRequests,
+		Times:      times,
+		Header:     map[string]string{"X-RateLimit-Reset-Seconds-Org": fmt.Sprintf("%d", resetSeconds)},
+	})
+}
+
+// ClearFaults removes every registered fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns the requests received by the fake server, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// ResetRequests clears the recorded requests.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
+
+// serveHTTP dispatches a request to the matching fault or route.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requestID++
+	w.Header().Set("X-Request-Id", fmt.Sprintf("fake-%d", s.requestID))
+
+	var routes []route
+	var path string
+	switch {
+	case strings.HasPrefix(r.URL.Path, APIv2Path+"/"):
+		routes = s.v2Routes
+		path = strings.TrimPrefix(r.URL.Path, APIv2Path)
+	case strings.HasPrefix(r.URL.Path, APIv3Path+"/"):
+		routes = s.v3Routes
+		path = strings.TrimPrefix(r.URL.Path, APIv3Path)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown path %s", r.URL.Path))
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.RawQuery, Body: string(body)})
+
+	if s.applyFault(w, r.Method, path) {
+		return
+	}
+
+	for _, rt := range routes {
+		params, ok := rt.match(r.Method, path)
+		if !ok {
+			continue
+		}
+
+		rt.handler(&call{w: w, r: r, body: body, params: params})
+		return
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, path))
+}
+
+// applyFault writes the first matching fault, if any, and returns true if one was written.
+func (s *Server) applyFault(w http.ResponseWriter, method, path string) bool {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, method) {
+			continue
+		}
+		if !strings.HasPrefix(path, f.PathPrefix) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		for k, v := range f.Header {
+			w.Header().Set(k, v)
+		}
+
+		msg := f.Message
+		if msg == "" {
+			msg = http.StatusText(f.StatusCode)
+		}
+		writeError(w, f.StatusCode, msg)
+		return true
+	}
+
+	return false
+}
+
+// route maps a method and a path pattern, such as /splits/ws/{ws}/{name}, to a handler.
+type route struct {
+	method   string
+	segments []string
+	handler  func(c *call)
+}
+
+// match returns the path parameters if the route matches the method and path.
+func (rt route) match(method, path string) (map[string]string, bool) {
+	if rt.method != method {
+		return nil, false
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, seg := range rt.segments {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			params[strings.Trim(seg, "{}")] = segments[i]
+			continue
+		}
+		if seg != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// handle registers an APIv2 route. Routes are matched in the order they are registered.
+func (s *Server) handle(method, pattern string, handler func(c *call)) {
+	s.v2Routes = append(s.v2Routes, route{method: method, segments: strings.Split(strings.Trim(pattern, "/"), "/"), handler: handler})
+}
+
+// handleV3 registers an APIv3 route. Routes are matched in the order they are registered.
+func (s *Server) handleV3(method, pattern string, handler func(c *call)) {
+	s.v3Routes = append(s.v3Routes, route{method: method, segments: strings.Split(strings.Trim(pattern, "/"), "/"), handler: handler})
+}
+
+// call holds the state of a single request being handled.
+type call struct {
+	w      http.ResponseWriter
+	r      *http.Request
+	body   []byte
+	params map[string]string
+}
+
+// param returns a path parameter.
+func (c *call) param(name string) string {
+	return c.params[name]
+}
+
+// query returns a query string parameter.
+func (c *call) query(name string) string {
+	return c.r.URL.Query().Get(name)
+}
+
+// decode unmarshals the request body into v. It writes a 400 response and returns false on failure.
+func (c *call) decode(v interface{}) bool {
+	if err := json.Unmarshal(c.body, v); err != nil {
+		writeError(c.w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+// json writes v as a JSON response.
+func (c *call) json(status int, v interface{}) {
+	writeJSON(c.w, status, v)
+}
+
+// error writes an error response.
+func (c *call) error(status int, format string, args ...interface{}) {
+	writeError(c.w, status, fmt.Sprintf(format, args...))
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format returned by the Split API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, api.SplitError{
+		Code:    intPtr(status),
+		Message: strPtr(message),
+	})
+}
+
+// newID generates a random UUID.
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func strPtr(v string) *string { return &v }
+
+func intPtr(v int) *int { return &v }
+
+func boolPtr(v bool) *bool { return &v }
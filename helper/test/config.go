@@ -18,6 +18,7 @@ const (
 	TestConfigSplitTrafficTypeID
 	TestConfigSplitUserEmail
 	TestConfigSplitHarnessToken
+	TestConfigSplitFakeAPI
 	TestConfigAcceptanceTestKey
 )
 
@@ -30,6 +31,7 @@ var testConfigKeyToEnvName = map[TestConfigKey]string{
 	TestConfigSplitEnvironmentID:   "SPLIT_ENVIRONMENT_ID",
 	TestConfigSplitUserEmail:       "SPLIT_USER_EMAIL",
 	TestConfigSplitHarnessToken:    "HARNESS_TOKEN",
+	TestConfigSplitFakeAPI:         "SPLIT_FAKE_API",
 	TestConfigAcceptanceTestKey:    resource.EnvTfAcc,
 }
 
@@ -0,0 +1,197 @@
+package split
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// defaultChangeRequestPollInterval is the default number of seconds between checks of a change request's status.
+	defaultChangeRequestPollInterval = 10
+)
+
+// changeRequestOptions configures how changes are submitted as change requests.
+type changeRequestOptions struct {
+	workspaceID  string
+	approvers    []string
+	pollInterval time.Duration
+}
+
+// changeRequestSchema returns the schema of the change_request block. When the block is set, changes are
+// submitted as change requests awaiting approval instead of being applied directly.
+//
+// Resources without a workspace_id attribute require the workspace in the block.
+func changeRequestSchema(withWorkspaceID bool) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"approvers": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+
+		"poll_interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultChangeRequestPollInterval,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+	}
+
+	if withWorkspaceID {
+		s["workspace_id"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsUUID,
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+	}
+}
+
+// getChangeRequestOptions returns the change request options of a resource, or nil when changes are applied directly.
+func getChangeRequestOptions(d *schema.ResourceData) *changeRequestOptions {
+	v, ok := d.GetOk("change_request")
+	if !ok {
+		return nil
+	}
+
+	opts := &changeRequestOptions{
+		workspaceID:  getWorkspaceID(d),
+		pollInterval: defaultChangeRequestPollInterval * time.Second,
+	}
+
+	vL := v.([]interface{})
+	if len(vL) == 0 || vL[0] == nil {
+		// An empty block enables change requests with the defaults.
+		return opts
+	}
+
+	raw := vL[0].(map[string]interface{})
+	if v, ok := raw["workspace_id"]; ok {
+		opts.workspaceID = v.(string)
+	}
+	if v, ok := raw["poll_interval"]; ok {
+		opts.pollInterval = time.Duration(v.(int)) * time.Second
+	}
+	for _, approver := range raw["approvers"].(*schema.Set).List() {
+		opts.approvers = append(opts.approvers, approver.(string))
+	}
+
+	return opts
+}
+
+// submitChangeRequest submits a change request and waits until it is approved. Rejected and withdrawn change
+// requests, as well as change requests still open when the timeout expires, are reported as errors.
+func submitChangeRequest(ctx context.Context, client *api.Client, environmentID string, opts *changeRequestOptions,
+	req *api.ChangeRequestRequest, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	req.Approvers = opts.approvers
+
+	log.Printf("[DEBUG] Submitting %s change request in environment %s", req.OperationType, environmentID)
+
+	cr, _, createErr := client.ChangeRequests.Create(ctx, opts.workspaceID, environmentID, req)
+	if createErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to submit %s change request in environment %s", req.OperationType, environmentID),
+			Detail:   createErr.Error(),
+		})
+		return diags
+	}
+
+	id := cr.GetID()
+	log.Printf("[DEBUG] Submitted change request %s, waiting for approval", id)
+
+	conf := &retry.StateChangeConf{
+		Pending: []string{api.ChangeRequestStatusRequested},
+		Target:  []string{api.ChangeRequestStatusApproved, api.ChangeRequestStatusPublished},
+		Refresh: func() (interface{}, string, error) {
+			cr, _, err := client.ChangeRequests.Get(ctx, id)
+			if err != nil {
+				return nil, "", err
+			}
+			return cr, cr.GetStatus(), nil
+		},
+		Timeout:      timeout,
+		PollInterval: opts.pollInterval,
+	}
+
+	result, waitErr := conf.WaitForStateContext(ctx)
+	if waitErr == nil {
+		log.Printf("[DEBUG] Change request %s approved", id)
+		return diags
+	}
+
+	var timeoutErr *retry.TimeoutError
+	if errors.As(waitErr, &timeoutErr) && timeoutErr.LastError == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Timed out waiting for change request %s to be approved", id),
+			Detail: fmt.Sprintf("The change request is still open after %s and is applied if approved later. "+
+				"Increase the resource timeouts to wait longer.", timeout),
+		})
+		return diags
+	}
+
+	if cr, ok := result.(*api.ChangeRequest); ok && cr != nil {
+		switch cr.GetStatus() {
+		case api.ChangeRequestStatusRejected:
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Change request %s was rejected", id),
+				Detail:   changeRequestCommentsDetail(cr, "APPROVER"),
+			})
+			return diags
+		case api.ChangeRequestStatusWithdrawn:
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Change request %s was withdrawn", id),
+				Detail:   changeRequestCommentsDetail(cr, "SUBMITTER"),
+			})
+			return diags
+		}
+	}
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Unable to wait for change request %s to be approved", id),
+		Detail:   waitErr.Error(),
+	})
+	return diags
+}
+
+// changeRequestCommentsDetail returns the comments left on the change request by users of the given role.
+func changeRequestCommentsDetail(cr *api.ChangeRequest, role string) string {
+	comments := make([]string, 0)
+	for _, c := range cr.Comments {
+		if c.GetRole() == role && c.GetComment() != "" {
+			comments = append(comments, c.GetComment())
+		}
+	}
+
+	if len(comments) == 0 {
+		return "No comment was left on the change request."
+	}
+
+	return strings.Join(comments, "\n")
+}
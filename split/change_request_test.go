@@ -0,0 +1,70 @@
+package split
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
+)
+
+func TestSubmitChangeRequest(t *testing.T) {
+	cases := []struct {
+		name       string
+		resolution string
+		summary    string
+	}{
+		{name: "approved", resolution: api.ChangeRequestStatusApproved},
+		{name: "rejected", resolution: api.ChangeRequestStatusRejected, summary: "was rejected"},
+		{name: "withdrawn", resolution: api.ChangeRequestStatusWithdrawn, summary: "was withdrawn"},
+		{name: "timed out", summary: "Timed out waiting for change request"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := fakesplit.New()
+			defer server.Close()
+
+			fixtures := server.Seed()
+			server.AutoResolveChangeRequests(tc.resolution)
+
+			client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+			if err != nil {
+				t.Fatalf("unable to construct client: %s", err)
+			}
+
+			ctx := context.Background()
+			if _, _, err := client.Segments.Create(ctx, fixtures.Workspace.GetID(), fixtures.TrafficType.GetID(), &api.SegmentRequest{Name: "beta"}); err != nil {
+				t.Fatalf("unable to create segment: %s", err)
+			}
+			if _, _, err := client.Segments.Activate(ctx, fixtures.Environment.GetID(), "beta"); err != nil {
+				t.Fatalf("unable to activate segment: %s", err)
+			}
+
+			opts := &changeRequestOptions{
+				workspaceID:  fixtures.Workspace.GetID(),
+				pollInterval: 10 * time.Millisecond,
+			}
+			req := constructSegmentKeysChangeRequest("beta", []string{"a", "b"}, "", "")
+
+			diags := submitChangeRequest(ctx, client, fixtures.Environment.GetID(), opts, req, 100*time.Millisecond)
+			if tc.summary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %+v", diags)
+				}
+
+				keys, _, err := client.Environments.GetSegmentKeys(ctx, fixtures.Environment.GetID(), "beta")
+				if err != nil || len(keys.Keys) != 2 {
+					t.Fatalf("expected the approved keys to be applied, got %+v: %v", keys, err)
+				}
+				return
+			}
+
+			if len(diags) != 1 || !strings.Contains(diags[0].Summary, tc.summary) {
+				t.Fatalf("expected a diagnostic containing %q, got: %+v", tc.summary, diags)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package split
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/davidji99/terraform-provider-split/api"
 	"github.com/davidji99/terraform-provider-split/version"
@@ -20,10 +24,21 @@ type Config struct {
 	apiKey       string
 	harnessToken string
 	apiBaseURL   string
+	apiV3BaseURL string
 
-	clientTimeout int
+	clientTimeout  int
+	requestTimeout int
+
+	retryOpts []api.Option
 
 	RemoveEnvFromStateOnly bool
+
+	DefaultChangeTitle   string
+	DefaultChangeComment string
+
+	// workspacesRequiringTitleAndComments caches whether a workspace requires a title and comment for changes.
+	workspacesRequiringTitleAndComments map[string]bool
+	workspacesMu                        sync.Mutex
 }
 
 func NewConfig() *Config {
@@ -40,6 +55,16 @@ func (c *Config) initializeAPI() error {
 		api.ClientTimeout(c.clientTimeout),
 	}
 
+	if c.apiV3BaseURL != "" {
+		opts = append(opts, api.APIv3BaseURL(c.apiV3BaseURL))
+	}
+
+	if c.requestTimeout > 0 {
+		opts = append(opts, api.RequestTimeout(time.Duration(c.requestTimeout)*time.Second))
+	}
+
+	opts = append(opts, c.retryOpts...)
+
 	// Use harness_token if provided, otherwise use api_key
 	if c.harnessToken != "" {
 		log.Printf("[INFO] Using harness_token for authentication")
@@ -78,14 +103,79 @@ func (c *Config) applySchema(d *schema.ResourceData) (err error) {
 		c.apiBaseURL = vs
 	}
 
+	if v, ok := d.GetOk("base_url_v3"); ok {
+		vs := v.(string)
+		c.apiV3BaseURL = vs
+	}
+
+	if c.apiBaseURL != "" && c.apiV3BaseURL == "" {
+		if _, err := api.DeriveAPIv3BaseURL(c.apiBaseURL); err != nil {
+			return fmt.Errorf("base_url_v3 must be set as it cannot be derived from base_url %q, "+
+				"which does not end with \"/internal/api/v2\"", c.apiBaseURL)
+		}
+	}
+
 	if v, ok := d.GetOk("harness_token"); ok {
 		vs := v.(string)
 		c.harnessToken = vs
 	}
 
+	if v, ok := d.GetOk("retry"); ok {
+		vL := v.([]interface{})
+		if len(vL) > 0 && vL[0] != nil {
+			retry := vL[0].(map[string]interface{})
+
+			c.retryOpts = append(c.retryOpts,
+				api.RetryMaxAttempts(retry["max_attempts"].(int)),
+				api.RetryBackoff(time.Duration(retry["base_backoff"].(int))*time.Second,
+					time.Duration(retry["max_backoff"].(int))*time.Second),
+				api.RetryJitter(retry["jitter"].(bool)),
+			)
+
+			if codesRaw := retry["retryable_status_codes"].(*schema.Set).List(); len(codesRaw) > 0 {
+				codes := make([]int, 0, len(codesRaw))
+				for _, code := range codesRaw {
+					codes = append(codes, code.(int))
+				}
+				c.retryOpts = append(c.retryOpts, api.RetryableStatusCodes(codes...))
+			}
+		}
+	}
+
 	if v, ok := d.GetOk("remove_environment_from_state_only"); ok {
 		c.RemoveEnvFromStateOnly = v.(bool)
 	}
 
+	if v, ok := d.GetOk("default_change_title"); ok {
+		c.DefaultChangeTitle = os.ExpandEnv(v.(string))
+	}
+
+	if v, ok := d.GetOk("default_change_comment"); ok {
+		c.DefaultChangeComment = os.ExpandEnv(v.(string))
+	}
+
 	return nil
 }
+
+// workspaceRequiresTitleAndComments returns whether changes in the workspace must have a title and comment.
+// The result is cached as looking up a workspace requires listing all of them.
+func (c *Config) workspaceRequiresTitleAndComments(ctx context.Context, workspaceID string) (bool, error) {
+	c.workspacesMu.Lock()
+	defer c.workspacesMu.Unlock()
+
+	if required, ok := c.workspacesRequiringTitleAndComments[workspaceID]; ok {
+		return required, nil
+	}
+
+	w, _, err := c.API.Workspaces.FindById(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+
+	if c.workspacesRequiringTitleAndComments == nil {
+		c.workspacesRequiringTitleAndComments = make(map[string]bool)
+	}
+	c.workspacesRequiringTitleAndComments[workspaceID] = w.GetRequiresTitleAndComments()
+
+	return w.GetRequiresTitleAndComments(), nil
+}
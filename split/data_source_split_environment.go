@@ -36,7 +36,7 @@ func dataSourceSplitEnvironmentRead(ctx context.Context, d *schema.ResourceData,
 	name := d.Get("name").(string)
 	workspaceID := d.Get("workspace_id").(string)
 
-	env, _, findErr := client.Environments.FindByName(workspaceID, name)
+	env, _, findErr := client.Environments.FindByName(ctx, workspaceID, name)
 	if findErr != nil {
 		return diag.FromErr(findErr)
 	}
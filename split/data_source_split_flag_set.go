@@ -36,7 +36,7 @@ func dataSourceSplitFlagSetRead(ctx context.Context, d *schema.ResourceData, m i
 	name := d.Get("name").(string)
 	workspaceID := d.Get("workspace_id").(string)
 
-	fs, _, findErr := client.FlagSets.FindByName(workspaceID, name)
+	fs, _, findErr := client.FlagSets.FindByName(ctx, workspaceID, name)
 	if findErr != nil {
 		return diag.FromErr(findErr)
 	}
@@ -46,7 +46,7 @@ func dataSourceSplitTrafficTypeRead(ctx context.Context, d *schema.ResourceData,
 	name := d.Get("name").(string)
 	workspaceID := d.Get("workspace_id").(string)
 
-	trafficType, _, findErr := client.TrafficTypes.FindByName(workspaceID, name)
+	trafficType, _, findErr := client.TrafficTypes.FindByName(ctx, workspaceID, name)
 	if findErr != nil {
 		return diag.FromErr(findErr)
 	}
@@ -33,7 +33,7 @@ func dataSourceSplitWorkspaceRead(ctx context.Context, d *schema.ResourceData, m
 
 	name := d.Get("name").(string)
 
-	workspace, _, findErr := client.Workspaces.FindByName(name)
+	workspace, _, findErr := client.Workspaces.FindByName(ctx, name)
 	if findErr != nil {
 		return diag.FromErr(findErr)
 	}
@@ -1,12 +1,109 @@
 package split
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
 	"strings"
+	"time"
 )
 
+const (
+	// defaultOperationTimeout is the default time allowed for a single create, read, update or delete operation.
+	defaultOperationTimeout = 5 * time.Minute
+)
+
+// defaultResourceTimeouts returns the default operation timeouts for a resource.
+// The update timeout is only set for resources that support in-place updates.
+func defaultResourceTimeouts(updatable bool) *schema.ResourceTimeout {
+	t := &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultOperationTimeout),
+		Read:   schema.DefaultTimeout(defaultOperationTimeout),
+		Delete: schema.DefaultTimeout(defaultOperationTimeout),
+	}
+
+	if updatable {
+		t.Update = schema.DefaultTimeout(defaultOperationTimeout)
+	}
+
+	return t
+}
+
+// resourceGetter is implemented by both *schema.ResourceData and *schema.ResourceDiff.
+type resourceGetter interface {
+	Get(key string) interface{}
+}
+
+// changeTitleAndComment returns the title and comment recorded with a change made by a resource,
+// falling back to the provider defaults when the resource does not set them.
+func changeTitleAndComment(d resourceGetter, config *Config) (title, comment string) {
+	title = d.Get("title").(string)
+	if title == "" {
+		title = config.DefaultChangeTitle
+	}
+
+	comment = d.Get("comment").(string)
+	if comment == "" {
+		comment = config.DefaultChangeComment
+	}
+
+	return title, comment
+}
+
+// validateChangeTitleAndComment fails the plan of a resource whose workspace requires a title and comment
+// for every change when neither the resource nor the provider configure them.
+func validateChangeTitleAndComment(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return validateChangeNotes(ctx, d, meta, true)
+}
+
+// validateChangeComment is like validateChangeTitleAndComment for changes that are only recorded with a comment.
+func validateChangeComment(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return validateChangeNotes(ctx, d, meta, false)
+}
+
+// validateChangeNotes fails the plan of a resource whose workspace requires a title and comment for every change
+// when the comment, and the title if withTitle is set, are configured by neither the resource nor the provider.
+func validateChangeNotes(ctx context.Context, d *schema.ResourceDiff, meta interface{}, withTitle bool) error {
+	config, ok := meta.(*Config)
+	if !ok || config == nil {
+		return nil
+	}
+
+	// Nothing is sent to Split when the resource does not change.
+	if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+
+	if !d.NewValueKnown("workspace_id") || !d.NewValueKnown("title") || !d.NewValueKnown("comment") {
+		return nil
+	}
+
+	title, comment := changeTitleAndComment(d, config)
+	if comment != "" && (title != "" || !withTitle) {
+		return nil
+	}
+
+	workspaceID := d.Get("workspace_id").(string)
+	required, err := config.workspaceRequiresTitleAndComments(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("unable to determine whether workspace %s requires a title and comment: %w", workspaceID, err)
+	}
+
+	if required && withTitle {
+		return fmt.Errorf("workspace %s requires a title and comment for every change: set title and comment "+
+			"on the resource or default_change_title and default_change_comment on the provider", workspaceID)
+	}
+
+	if required {
+		return fmt.Errorf("workspace %s requires a comment for every change: set comment "+
+			"on the resource or default_change_comment on the provider", workspaceID)
+	}
+
+	return nil
+}
+
 // getWorkspaceID extracts the workspace ID attribute generically from a Split resource.
 func getWorkspaceID(d *schema.ResourceData) string {
 	var workspaceID string
@@ -55,6 +152,39 @@ func getSplitName(d *schema.ResourceData) string {
 	return n
 }
 
+// getTags extracts the tags attribute generically from a Split resource.
+func getTags(d *schema.ResourceData) []string {
+	tags := make([]string, 0)
+	if v, ok := d.GetOk("tags"); ok {
+		tags = setToStringSlice(v.(*schema.Set))
+		log.Printf("[DEBUG] tags: %v", tags)
+	}
+
+	return tags
+}
+
+// setToStringSlice converts a set of strings to a slice.
+func setToStringSlice(s *schema.Set) []string {
+	values := make([]string, 0, s.Len())
+	for _, v := range s.List() {
+		values = append(values, v.(string))
+	}
+
+	return values
+}
+
+// tagsSchema returns the schema of the tags attribute shared by resources that can be tagged.
+func tagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeSet,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+		Optional: true,
+	}
+}
+
 func parseCompositeID(id string, numOfSplits int) ([]string, error) {
 	parts := strings.SplitN(id, ":", numOfSplits)
 
@@ -20,7 +20,7 @@ func TestAccSplitSegment_importBasic(t *testing.T) {
 		ProviderFactories: testAccProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, "created from Terraform"),
+				Config: testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, "created from Terraform", "team-a"),
 			},
 			{
 				ResourceName:      "split_segment.foobar",
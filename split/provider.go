@@ -7,6 +7,7 @@ import (
 	"github.com/davidji99/terraform-provider-split/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func New() *schema.Provider {
@@ -31,6 +32,13 @@ func New() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("SPLIT_API_URL", api.DefaultAPIBaseURL),
 			},
 
+			"base_url_v3": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SPLIT_API_V3_URL", nil),
+				Description: "Custom APIv3 URL used by resources only available in the Split APIv3, such as flag sets. Derived from base_url when not set, which requires base_url to end with /internal/api/v2.",
+			},
+
 			"headers": {
 				Type:     schema.TypeMap,
 				Elem:     schema.TypeString,
@@ -38,9 +46,76 @@ func New() *schema.Provider {
 			},
 
 			"client_timeout": {
-				Type:     schema.TypeInt,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      api.DefaultClientTimeout,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(api.DefaultRequestTimeout.Seconds()),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"retry": {
+				Type:     schema.TypeList,
 				Optional: true,
-				Default:  300, // 5 min
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      api.DefaultRetryMaxAttempts,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"base_backoff": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(api.DefaultRetryBaseBackoff.Seconds()),
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"max_backoff": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      int(api.DefaultRetryMaxBackoff.Seconds()),
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+
+						"jitter": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"retryable_status_codes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(400, 599),
+							},
+						},
+					},
+				},
+			},
+
+			"default_change_title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SPLIT_DEFAULT_CHANGE_TITLE", nil),
+				Description: "Title recorded with every change when a resource does not set its own. Environment variables such as $CI_RUN_ID are expanded.",
+			},
+
+			"default_change_comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SPLIT_DEFAULT_CHANGE_COMMENT", nil),
+				Description: "Comment recorded with every change when a resource does not set its own. Environment variables such as $CI_RUN_ID are expanded.",
 			},
 
 			"remove_environment_from_state_only": {
@@ -63,10 +138,13 @@ func New() *schema.Provider {
 			"split_environment_segment_keys":        resourceSplitEnvironmentSegmentKeys(),
 			"split_flag_set":                        resourceSplitFlagSet(),
 			"split_group":                           resourceSplitGroupWithDeprecation(),
+			"split_group_membership":                resourceSplitGroupMembershipWithDeprecation(),
+			"split_progressive_rollout":             resourceSplitProgressiveRollout(),
 			"split_segment":                         resourceSplitSegment(),
 			"split_segment_environment_association": resourceSplitSegmentEnvironmentAssociation(),
 			"split_split":                           resourceSplitSplit(),
 			"split_split_definition":                resourceSplitSplitDefinition(),
+			"split_split_definition_promotion":      resourceSplitSplitDefinitionPromotion(),
 			"split_traffic_type":                    resourceSplitTrafficType(),
 			"split_traffic_type_attribute":          resourceSplitTrafficTypeAttribute(),
 			"split_user":                            resourceSplitUserWithDeprecation(),
@@ -106,6 +184,10 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		config.clientTimeout = clientTimeout.(int)
 	}
 
+	if requestTimeout, ok := d.GetOk("request_timeout"); ok {
+		config.requestTimeout = requestTimeout.(int)
+	}
+
 	if err := config.initializeAPI(); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -83,6 +83,22 @@ func TestDeprecatedResourcesWithHarnessToken(t *testing.T) {
 			wantErr:    true,
 			errMessage: "Resource split_group cannot be used when harness_token is set",
 		},
+		{
+			name:         "split_group_membership resource error with harness_token",
+			resourceName: "split_group_membership",
+			config: `
+				provider "split" {
+					harness_token = "test-token"
+				}
+				
+				resource "split_group_membership" "test" {
+					group_id = "0b46d8f7-9435-4f74-a770-3fcb22fbbfe6"
+					user_ids = ["user-id"]
+				}
+			`,
+			wantErr:    true,
+			errMessage: "Resource split_group_membership cannot be used when harness_token is set",
+		},
 		{
 			name:         "split_workspace resource error with harness_token",
 			resourceName: "split_workspace",
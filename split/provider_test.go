@@ -1,8 +1,14 @@
 package split
 
 import (
+	"context"
+	"log"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
 	helper "github.com/davidji99/terraform-provider-split/helper/test"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,6 +28,39 @@ func init() {
 	testAccConfig = helper.NewTestConfig()
 }
 
+// TestMain runs the tests against an in-memory fake of the Split API when SPLIT_FAKE_API is set.
+// The fake is seeded with the workspace, environment, traffic type and user the acceptance tests expect.
+func TestMain(m *testing.M) {
+	if testAccConfig.Get(helper.TestConfigSplitFakeAPI) == "" {
+		os.Exit(m.Run())
+	}
+
+	server := fakesplit.New()
+	fixtures := server.Seed()
+
+	env := map[string]string{
+		"SPLIT_API_URL":           server.APIBaseURL(),
+		"SPLIT_API_V3_URL":        server.APIv3BaseURL(),
+		"SPLIT_API_KEY":           "fake-api-key",
+		"HARNESS_TOKEN":           "",
+		"SPLIT_WORKSPACE_ID":      fixtures.Workspace.GetID(),
+		"SPLIT_WORKSPACE_NAME":    fixtures.Workspace.GetName(),
+		"SPLIT_ENVIRONMENT_ID":    fixtures.Environment.GetID(),
+		"SPLIT_TRAFFIC_TYPE_ID":   fixtures.TrafficType.GetID(),
+		"SPLIT_TRAFFIC_TYPE_NAME": fixtures.TrafficType.GetName(),
+		"SPLIT_USER_EMAIL":        fixtures.User.GetEmail(),
+	}
+	for k, v := range env {
+		if err := os.Setenv(k, v); err != nil {
+			log.Fatalf("unable to set %s: %s", k, err)
+		}
+	}
+
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := New().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -32,6 +71,107 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = New()
 }
 
+func TestProviderWithRetry(t *testing.T) {
+	p := New()
+
+	raw := map[string]interface{}{
+		"api_key": "test-api-key",
+		"retry": []interface{}{
+			map[string]interface{}{
+				"max_attempts":           3,
+				"base_backoff":           2,
+				"max_backoff":            10,
+				"jitter":                 false,
+				"retryable_status_codes": []interface{}{429, 503},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, p.Schema, raw)
+	_, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no error with retry block, got: %+v", diags)
+	}
+}
+
+func TestProviderWithRetry_InvalidBackoff(t *testing.T) {
+	p := New()
+
+	raw := map[string]interface{}{
+		"api_key": "test-api-key",
+		"retry": []interface{}{
+			map[string]interface{}{
+				"base_backoff": 10,
+				"max_backoff":  2,
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, p.Schema, raw)
+	_, diags := p.ConfigureContextFunc(context.Background(), d)
+	if !diags.HasError() {
+		t.Fatal("Expected an error when base_backoff exceeds max_backoff")
+	}
+}
+
+func TestProviderWithBaseURL(t *testing.T) {
+	p := New()
+
+	raw := map[string]interface{}{
+		"api_key":  "test-api-key",
+		"base_url": "https://split.example.com/internal/api/v2",
+	}
+
+	d := schema.TestResourceDataRaw(t, p.Schema, raw)
+	_, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected base_url_v3 to be derived from base_url, got: %+v", diags)
+	}
+}
+
+func TestProviderWithBaseURL_Underivable(t *testing.T) {
+	p := New()
+
+	raw := map[string]interface{}{
+		"api_key":  "test-api-key",
+		"base_url": "https://split.example.com/api",
+	}
+
+	d := schema.TestResourceDataRaw(t, p.Schema, raw)
+	_, diags := p.ConfigureContextFunc(context.Background(), d)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "base_url_v3 must be set") {
+		t.Fatalf("Expected an error asking to set base_url_v3, got: %+v", diags)
+	}
+
+	raw["base_url_v3"] = "https://split.example.com/api/v3"
+	d = schema.TestResourceDataRaw(t, p.Schema, raw)
+	if _, diags := p.ConfigureContextFunc(context.Background(), d); diags.HasError() {
+		t.Fatalf("Expected no error when base_url_v3 is set, got: %+v", diags)
+	}
+}
+
+func TestProviderWithDefaultChange(t *testing.T) {
+	t.Setenv("CI_RUN_ID", "42")
+	p := New()
+
+	raw := map[string]interface{}{
+		"api_key":                "test-api-key",
+		"default_change_title":   "terraform apply",
+		"default_change_comment": "applied by CI run $CI_RUN_ID",
+	}
+
+	d := schema.TestResourceDataRaw(t, p.Schema, raw)
+	meta, diags := p.ConfigureContextFunc(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("Expected no error, got: %+v", diags)
+	}
+
+	config := meta.(*Config)
+	if config.DefaultChangeTitle != "terraform apply" || config.DefaultChangeComment != "applied by CI run 42" {
+		t.Fatalf("unexpected defaults: %q, %q", config.DefaultChangeTitle, config.DefaultChangeComment)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	// First check if TF_ACC is set - skip gracefully if not
 	testAccConfig.SkipUnlessAccTest(t)
@@ -61,3 +201,19 @@ func testAccProviderFactoriesInit(providers []*schema.Provider, providerNames []
 
 	return factories
 }
+
+// testAccAPIClient returns an API client authenticated with the acceptance test credentials.
+func testAccAPIClient() (*api.Client, error) {
+	var opts []api.Option
+	if baseURL := os.Getenv("SPLIT_API_URL"); baseURL != "" {
+		opts = append(opts, api.APIBaseURL(baseURL))
+	}
+	if baseURLv3 := os.Getenv("SPLIT_API_V3_URL"); baseURLv3 != "" {
+		opts = append(opts, api.APIv3BaseURL(baseURLv3))
+	}
+
+	if harnessToken := testAccConfig.Get(helper.TestConfigSplitHarnessToken); harnessToken != "" {
+		return api.New(append(opts, api.HarnessToken(harnessToken))...)
+	}
+	return api.New(append(opts, api.APIKey(testAccConfig.Get(helper.TestConfigSplitAPIKey)))...)
+}
@@ -17,6 +17,8 @@ func resourceSplitApiKey() *schema.Resource {
 		ReadContext:   resourceSplitApiKeyRead,
 		DeleteContext: resourceSplitApiKeyDelete,
 
+		Timeouts: defaultResourceTimeouts(false),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitApiKeyImport,
 		},
@@ -123,7 +125,7 @@ func resourceSplitApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Creating new api key %v", opts.Name)
 
-	apiKey, _, createErr := client.ApiKeys.Create(opts)
+	apiKey, _, createErr := client.ApiKeys.Create(ctx, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -154,7 +156,7 @@ func resourceSplitApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta
 	apiKeyTrunc := Truncate(d.Id(), 4)
 
 	log.Printf("[DEBUG] Deleting API key [%s]", apiKeyTrunc)
-	_, deleteErr := client.ApiKeys.Delete(d.Id())
+	_, deleteErr := client.ApiKeys.Delete(ctx, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -17,6 +17,8 @@ func resourceSplitEnvironment() *schema.Resource {
 		UpdateContext: resourceSplitEnvironmentUpdate,
 		DeleteContext: resourceSplitEnvironmentDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitEnvironmentImport,
 		},
@@ -64,7 +66,7 @@ func resourceSplitEnvironmentImport(ctx context.Context, d *schema.ResourceData,
 	workspaceID := importID[0]
 	envID := importID[1]
 
-	e, _, getErr := client.Environments.FindByID(workspaceID, envID)
+	e, _, getErr := client.Environments.FindByID(ctx, workspaceID, envID)
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -96,7 +98,7 @@ func resourceSplitEnvironmentCreate(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[DEBUG] Creating Environment named %v", opts.GetName())
 
-	e, _, createErr := client.Environments.Create(workspaceID, opts)
+	e, _, createErr := client.Environments.Create(ctx, workspaceID, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -129,8 +131,14 @@ func resourceSplitEnvironmentRead(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
 
-	e, _, getErr := client.Environments.FindByID(getWorkspaceID(d), d.Id())
+	e, _, getErr := client.Environments.FindByID(ctx, getWorkspaceID(d), d.Id())
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Environment %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch environment %s", d.Id()),
@@ -176,7 +184,7 @@ func resourceSplitEnvironmentUpdate(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[DEBUG] Updating environment")
 
-	_, _, updateErr := client.Environments.Update(getWorkspaceID(d), d.Id(), opts)
+	_, _, updateErr := client.Environments.Update(ctx, getWorkspaceID(d), d.Id(), opts)
 	if updateErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -204,7 +212,7 @@ func resourceSplitEnvironmentDelete(ctx context.Context, d *schema.ResourceData,
 			for _, tokenID := range tokenList {
 				tokenIDStr := tokenID.(string)
 				log.Printf("[DEBUG] Deleting API token %s for environment %s", tokenIDStr, d.Id())
-				_, deleteTokenErr := client.ApiKeys.Delete(tokenIDStr)
+				_, deleteTokenErr := client.ApiKeys.Delete(ctx, tokenIDStr)
 				if deleteTokenErr != nil {
 					log.Printf("[WARN] Error deleting API token %s: %s", tokenIDStr, deleteTokenErr.Error())
 					// Continue trying to delete other tokens and the environment
@@ -215,7 +223,7 @@ func resourceSplitEnvironmentDelete(ctx context.Context, d *schema.ResourceData,
 		}
 
 		log.Printf("[DEBUG] Deleting Environment %s", d.Id())
-		_, deleteErr := client.Environments.Delete(getWorkspaceID(d), d.Id())
+		_, deleteErr := client.Environments.Delete(ctx, getWorkspaceID(d), d.Id())
 		if deleteErr != nil {
 			// Provide helpful error message for token-related deletion issues
 			errorDetail := deleteErr.Error()
@@ -10,6 +10,12 @@ import (
 	"log"
 )
 
+const (
+	// defaultSegmentKeysChange is the title and comment of changes to segment keys when neither the resource nor
+	// the provider set one.
+	defaultSegmentKeysChange = "modified by Terraform"
+)
+
 func resourceSplitEnvironmentSegmentKeys() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSplitEnvironmentSegmentKeysCreate,
@@ -17,6 +23,10 @@ func resourceSplitEnvironmentSegmentKeys() *schema.Resource {
 		UpdateContext: resourceSplitEnvironmentSegmentKeysUpdate,
 		DeleteContext: resourceSplitEnvironmentSegmentKeysDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
+		CustomizeDiff: resourceSplitEnvironmentSegmentKeysCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitEnvironmentSegmentKeysImport,
 		},
@@ -42,7 +52,13 @@ func resourceSplitEnvironmentSegmentKeys() *schema.Resource {
 				},
 				Required: true,
 				MinItems: 1,
-				MaxItems: 10000,
+			},
+
+			"chunk_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      api.MaxSegmentKeysPerRequest,
+				ValidateFunc: validation.IntBetween(1, api.MaxSegmentKeysPerRequest),
 			},
 
 			"comment": {
@@ -51,6 +67,8 @@ func resourceSplitEnvironmentSegmentKeys() *schema.Resource {
 				Computed: true,
 			},
 
+			"change_request": changeRequestSchema(true),
+
 			"title": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -60,6 +78,21 @@ func resourceSplitEnvironmentSegmentKeys() *schema.Resource {
 	}
 }
 
+// resourceSplitEnvironmentSegmentKeysCustomizeDiff fails the plan when a change request would propose more keys than
+// a single request accepts, as change requests cannot be split into chunks.
+func resourceSplitEnvironmentSegmentKeysCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if len(diff.Get("change_request").([]interface{})) == 0 || !diff.NewValueKnown("keys") {
+		return nil
+	}
+
+	if n := diff.Get("keys").(*schema.Set).Len(); n > api.MaxSegmentKeysPerRequest {
+		return fmt.Errorf("change requests propose the entire set of keys, which is limited to %d keys, got %d",
+			api.MaxSegmentKeysPerRequest, n)
+	}
+
+	return nil
+}
+
 func resourceSplitEnvironmentSegmentKeysImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Config).API
 
@@ -71,7 +104,7 @@ func resourceSplitEnvironmentSegmentKeysImport(ctx context.Context, d *schema.Re
 	environmentID := importID[0]
 	segmentName := importID[1]
 
-	result, _, getErr := client.Environments.GetSegmentKeys(environmentID, segmentName)
+	keys, _, getErr := client.Environments.ListAllSegmentKeys(ctx, environmentID, segmentName)
 	if getErr != nil {
 		return nil, fmt.Errorf(fmt.Sprintf("unable to fetch environment %s segment %s's keys", environmentID, segmentName))
 	}
@@ -80,32 +113,23 @@ func resourceSplitEnvironmentSegmentKeysImport(ctx context.Context, d *schema.Re
 
 	d.Set("environment_id", environmentID)
 	d.Set("segment_name", segmentName)
-
-	keys := make([]string, 0)
-	for _, key := range result.Keys {
-		keys = append(keys, key.GetKey())
-	}
 	d.Set("keys", keys)
+	d.Set("chunk_size", api.MaxSegmentKeysPerRequest)
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceSplitEnvironmentSegmentKeysCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 	opts := &api.EnvironmentSegmentKeysRequest{}
 	environmentID := getEnvironmentID(d)
 	segmentName := d.Get("segment_name").(string)
 
-	if v, ok := d.GetOk("comment"); ok {
-		opts.Comment = v.(string)
-		log.Printf("[DEBUG] new env segment key comment is : %v", opts.Comment)
-	}
-
-	if v, ok := d.GetOk("title"); ok {
-		opts.Title = v.(string)
-		log.Printf("[DEBUG] new env segment key title is : %v", opts.Title)
-	}
+	opts.Title, opts.Comment = segmentKeysChangeTitleAndComment(d, config)
+	log.Printf("[DEBUG] new env segment key title is : %v", opts.Title)
+	log.Printf("[DEBUG] new env segment key comment is : %v", opts.Comment)
 
 	if v, ok := d.GetOk("keys"); ok {
 		vL := v.(*schema.Set).List()
@@ -117,19 +141,31 @@ func resourceSplitEnvironmentSegmentKeysCreate(ctx context.Context, d *schema.Re
 		log.Printf("[DEBUG] adding keys : %v", opts.Keys)
 	}
 
-	log.Printf("[DEBUG] Modifying segment keys to environment %s & segment %s", environmentID, segmentName)
-
-	_, _, addErr := client.Environments.AddSegmentKeys(environmentID, segmentName, true, opts)
-	if addErr != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("[DEBUG] Unable to add segment keys to environment %s & segment %s", environmentID, segmentName),
-			Detail:   addErr.Error(),
+	if cr := getChangeRequestOptions(d); cr != nil {
+		req := constructSegmentKeysChangeRequest(segmentName, opts.Keys, opts.Title, opts.Comment)
+		if crDiags := submitChangeRequest(ctx, client, environmentID, cr, req, d.Timeout(schema.TimeoutCreate)); crDiags.HasError() {
+			return crDiags
+		}
+	} else {
+		log.Printf("[DEBUG] Modifying segment keys to environment %s & segment %s", environmentID, segmentName)
+
+		// The first chunk replaces any existing keys, the others are added to it.
+		addErr := applySegmentKeysInChunks(ctx, d, opts.Keys, func(i int, chunk []string) error {
+			_, _, err := client.Environments.AddSegmentKeys(ctx, environmentID, segmentName, i == 0,
+				&api.EnvironmentSegmentKeysRequest{Keys: chunk, Title: opts.Title, Comment: opts.Comment})
+			return err
 		})
-		return diags
-	}
+		if addErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("[DEBUG] Unable to add segment keys to environment %s & segment %s", environmentID, segmentName),
+				Detail:   addErr.Error(),
+			})
+			return diags
+		}
 
-	log.Printf("[DEBUG] Added segment keys to environment %s & segment %s", environmentID, segmentName)
+		log.Printf("[DEBUG] Added segment keys to environment %s & segment %s", environmentID, segmentName)
+	}
 
 	d.SetId(fmt.Sprintf("%s:%s", environmentID, segmentName))
 
@@ -138,43 +174,52 @@ func resourceSplitEnvironmentSegmentKeysCreate(ctx context.Context, d *schema.Re
 
 func resourceSplitEnvironmentSegmentKeysUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 	environmentID := getEnvironmentID(d)
 	segmentName := d.Get("segment_name").(string)
+	title, comment := segmentKeysChangeTitleAndComment(d, config)
 
 	hasChange := d.HasChange("keys")
 	log.Printf("[INFO] Does segment environment association have changes: *%#v", hasChange)
 
-	var oldKeysRaw, newKeysRaw []interface{}
-	if hasChange {
-		o, n := d.GetChange("keys")
-		if o == nil {
-			o = []interface{}{}
-		}
-		if n == nil {
-			n = []interface{}{}
+	// Change requests propose the entire set of keys.
+	if cr := getChangeRequestOptions(d); cr != nil {
+		if hasChange {
+			keys := make([]string, 0)
+			for _, k := range d.Get("keys").(*schema.Set).List() {
+				keys = append(keys, k.(string))
+			}
+
+			req := constructSegmentKeysChangeRequest(segmentName, keys, title, comment)
+			if crDiags := submitChangeRequest(ctx, client, environmentID, cr, req, d.Timeout(schema.TimeoutUpdate)); crDiags.HasError() {
+				return crDiags
+			}
 		}
 
-		oldKeysRaw = o.(*schema.Set).List()
-		newKeysRaw = n.(*schema.Set).List()
+		return resourceSplitEnvironmentSegmentKeysRead(ctx, d, meta)
 	}
 
-	// First, remove the old keys
-	if len(oldKeysRaw) > 0 {
-		oldKeys := make([]string, 0, len(oldKeysRaw))
-		for _, k := range oldKeysRaw {
-			oldKeys = append(oldKeys, k.(string))
-		}
+	if !hasChange {
+		return resourceSplitEnvironmentSegmentKeysRead(ctx, d, meta)
+	}
 
-		log.Printf("[INFO] Removing the following keys from environment [%s] and segment [%s]: %v", environmentID, segmentName, oldKeys)
+	o, n := d.GetChange("keys")
+	oldKeys, newKeys := o.(*schema.Set), n.(*schema.Set)
 
-		opts := &api.EnvironmentSegmentKeysRequest{
-			Keys:    oldKeys,
-			Comment: "modified by Terraform",
-			Title:   "modified by Terraform",
-		}
-		_, err := client.Environments.RemoveSegmentKeys(environmentID, segmentName, opts)
+	// First, remove the keys that are no longer configured
+	if removed := setToStringSlice(oldKeys.Difference(newKeys)); len(removed) > 0 {
+		log.Printf("[INFO] Removing %d keys from environment [%s] and segment [%s]", len(removed), environmentID, segmentName)
+
+		err := applySegmentKeysInChunks(ctx, d, removed, func(_ int, chunk []string) error {
+			_, err := client.Environments.RemoveSegmentKeys(ctx, environmentID, segmentName,
+				&api.EnvironmentSegmentKeysRequest{Keys: chunk, Comment: comment, Title: title})
+			return err
+		})
 		if err != nil {
+			// Keep the previous keys in state so that the next plan still shows the entire difference.
+			d.Partial(true)
+
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  fmt.Sprintf("Unable to remove keys when updating environment [%s] and segment [%s]", environmentID, segmentName),
@@ -183,25 +228,22 @@ func resourceSplitEnvironmentSegmentKeysUpdate(ctx context.Context, d *schema.Re
 			return diags
 		}
 
-		log.Printf("[INFO] Removed the following keys from environment [%s] and segment [%s]: %v", environmentID, segmentName, oldKeys)
+		log.Printf("[INFO] Removed %d keys from environment [%s] and segment [%s]", len(removed), environmentID, segmentName)
 	}
 
 	// Then, add the new keys
-	if len(newKeysRaw) > 0 {
-		newKeys := make([]string, 0, len(newKeysRaw))
-		for _, k := range newKeysRaw {
-			newKeys = append(newKeys, k.(string))
-		}
-
-		log.Printf("[INFO] Adding the following keys from environment [%s] and segment [%s]: %v", environmentID, segmentName, newKeys)
+	if added := setToStringSlice(newKeys.Difference(oldKeys)); len(added) > 0 {
+		log.Printf("[INFO] Adding %d keys to environment [%s] and segment [%s]", len(added), environmentID, segmentName)
 
-		opts := &api.EnvironmentSegmentKeysRequest{
-			Keys:    newKeys,
-			Comment: "modified by Terraform",
-			Title:   "modified by Terraform",
-		}
-		_, _, err := client.Environments.AddSegmentKeys(environmentID, segmentName, true, opts)
+		err := applySegmentKeysInChunks(ctx, d, added, func(_ int, chunk []string) error {
+			_, _, err := client.Environments.AddSegmentKeys(ctx, environmentID, segmentName, false,
+				&api.EnvironmentSegmentKeysRequest{Keys: chunk, Comment: comment, Title: title})
+			return err
+		})
 		if err != nil {
+			// Keep the previous keys in state so that the next plan still shows the entire difference.
+			d.Partial(true)
+
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  fmt.Sprintf("Unable to add keys when updating environment [%s] and segment [%s]", environmentID, segmentName),
@@ -210,7 +252,7 @@ func resourceSplitEnvironmentSegmentKeysUpdate(ctx context.Context, d *schema.Re
 			return diags
 		}
 
-		log.Printf("[INFO] Added the following keys from environment [%s] and segment [%s]: %v", environmentID, segmentName, newKeys)
+		log.Printf("[INFO] Added %d keys to environment [%s] and segment [%s]", len(added), environmentID, segmentName)
 	}
 
 	return resourceSplitEnvironmentSegmentKeysRead(ctx, d, meta)
@@ -222,8 +264,14 @@ func resourceSplitEnvironmentSegmentKeysRead(ctx context.Context, d *schema.Reso
 	environmentID := getEnvironmentID(d)
 	segmentName := d.Get("segment_name").(string)
 
-	result, _, getErr := client.Environments.GetSegmentKeys(environmentID, segmentName)
+	keys, _, getErr := client.Environments.ListAllSegmentKeys(ctx, environmentID, segmentName)
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Environment segment keys %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch environment %s segment %s's keys", environmentID, segmentName),
@@ -234,11 +282,6 @@ func resourceSplitEnvironmentSegmentKeysRead(ctx context.Context, d *schema.Reso
 
 	d.Set("environment_id", environmentID)
 	d.Set("segment_name", segmentName)
-
-	keys := make([]string, 0)
-	for _, key := range result.Keys {
-		keys = append(keys, key.GetKey())
-	}
 	d.Set("keys", keys)
 
 	return diags
@@ -246,7 +289,8 @@ func resourceSplitEnvironmentSegmentKeysRead(ctx context.Context, d *schema.Reso
 
 func resourceSplitEnvironmentSegmentKeysDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 
 	result, parseErr := parseCompositeID(d.Id(), 2)
 	if parseErr != nil {
@@ -260,21 +304,29 @@ func resourceSplitEnvironmentSegmentKeysDelete(ctx context.Context, d *schema.Re
 	environmentId := result[0]
 	segmentName := result[1]
 
-	log.Printf("[DEBUG] Removing all segment keys from environment %s & segment %s due to resource deletion", environmentId, segmentName)
+	title, comment := segmentKeysChangeTitleAndComment(d, config)
 
-	keysRaw := d.Get("keys").(*schema.Set).List()
-	keys := make([]string, len(keysRaw))
-	for _, k := range keysRaw {
-		keys = append(keys, k.(string))
-	}
+	// Change requests propose the entire set of keys, which is empty once the resource is deleted.
+	if cr := getChangeRequestOptions(d); cr != nil {
+		req := constructSegmentKeysChangeRequest(segmentName, []string{}, title, comment)
+		if crDiags := submitChangeRequest(ctx, client, environmentId, cr, req, d.Timeout(schema.TimeoutDelete)); crDiags.HasError() {
+			return crDiags
+		}
 
-	opts := &api.EnvironmentSegmentKeysRequest{
-		Keys:    keys,
-		Comment: "modified by Terraform",
-		Title:   "modified by Terraform",
+		d.SetId("")
+
+		return diags
 	}
 
-	_, deleteErr := client.Environments.RemoveSegmentKeys(environmentId, segmentName, opts)
+	log.Printf("[DEBUG] Removing all segment keys from environment %s & segment %s due to resource deletion", environmentId, segmentName)
+
+	keys := setToStringSlice(d.Get("keys").(*schema.Set))
+
+	deleteErr := applySegmentKeysInChunks(ctx, d, keys, func(_ int, chunk []string) error {
+		_, err := client.Environments.RemoveSegmentKeys(ctx, environmentId, segmentName,
+			&api.EnvironmentSegmentKeysRequest{Keys: chunk, Comment: comment, Title: title})
+		return err
+	})
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -290,3 +342,51 @@ func resourceSplitEnvironmentSegmentKeysDelete(ctx context.Context, d *schema.Re
 
 	return diags
 }
+
+// segmentKeysChangeTitleAndComment returns the title and comment recorded with changes to the segment keys.
+func segmentKeysChangeTitleAndComment(d *schema.ResourceData, config *Config) (string, string) {
+	title, comment := changeTitleAndComment(d, config)
+	if title == "" {
+		title = defaultSegmentKeysChange
+	}
+	if comment == "" {
+		comment = defaultSegmentKeysChange
+	}
+
+	return title, comment
+}
+
+// applySegmentKeysInChunks calls apply with consecutive chunks of at most chunk_size keys, along with the index of
+// the chunk. Each chunk is retried by the client's retry policy, so a chunk that still fails stops the remaining ones.
+func applySegmentKeysInChunks(ctx context.Context, d *schema.ResourceData, keys []string, apply func(i int, chunk []string) error) error {
+	chunkSize := d.Get("chunk_size").(int)
+	if chunkSize <= 0 || chunkSize > api.MaxSegmentKeysPerRequest {
+		chunkSize = api.MaxSegmentKeysPerRequest
+	}
+
+	for i := 0; i*chunkSize < len(keys); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		chunk := keys[i*chunkSize : min((i+1)*chunkSize, len(keys))]
+		if err := apply(i, chunk); err != nil {
+			return fmt.Errorf("unable to apply chunk %d (%d keys starting at key %d): %w", i, len(chunk), i*chunkSize, err)
+		}
+	}
+
+	return nil
+}
+
+// constructSegmentKeysChangeRequest returns the change request proposing the keys of the segment.
+func constructSegmentKeysChangeRequest(segmentName string, keys []string, title, comment string) *api.ChangeRequestRequest {
+	return &api.ChangeRequestRequest{
+		Segment: &api.ChangeRequestSegment{
+			Name: segmentName,
+			Keys: keys,
+		},
+		OperationType: api.ChangeRequestOperationUpdate,
+		Title:         title,
+		Comment:       comment,
+	}
+}
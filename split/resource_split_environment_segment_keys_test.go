@@ -1,10 +1,20 @@
 package split
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAccSplitEnvironmentSegmentKeys_Basic(t *testing.T) {
@@ -59,6 +69,317 @@ func TestAccSplitEnvironmentSegmentKeys_Basic(t *testing.T) {
 	})
 }
 
+func TestSplitEnvironmentSegmentKeys_Chunks(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	workspaceID := fixtures.Workspace.GetID()
+	environmentID := fixtures.Environment.GetID()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()),
+		api.RetryMaxAttempts(2), api.RetryBackoff(time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	if _, _, err := client.Segments.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SegmentRequest{Name: "beta"}); err != nil {
+		t.Fatalf("unable to create segment: %s", err)
+	}
+	if _, _, err := client.Segments.Activate(ctx, environmentID, "beta"); err != nil {
+		t.Fatalf("unable to activate segment: %s", err)
+	}
+
+	keys := func(from, to int) []interface{} {
+		k := make([]interface{}, 0, to-from)
+		for i := from; i < to; i++ {
+			k = append(k, fmt.Sprintf("user-%05d", i))
+		}
+		return k
+	}
+
+	// keyRequests returns the number of keys sent to each matching request made since the given request.
+	keyRequests := func(since int, suffix string) []int {
+		counts := make([]int, 0)
+		for _, req := range server.Requests()[since:] {
+			if !strings.HasSuffix(req.Path, suffix) {
+				continue
+			}
+			var body api.EnvironmentSegmentKeysRequest
+			if err := json.Unmarshal([]byte(req.Body), &body); err != nil {
+				t.Fatalf("unable to decode request body: %s", err)
+			}
+			counts = append(counts, len(body.Keys))
+		}
+		return counts
+	}
+
+	raw := map[string]interface{}{
+		"environment_id": environmentID,
+		"segment_name":   "beta",
+		"keys":           keys(0, 2500),
+		"chunk_size":     1000,
+	}
+
+	// A transient failure of a chunk is retried by the client.
+	server.InjectFault(fakesplit.Fault{
+		Method:     http.MethodPut,
+		PathPrefix: fmt.Sprintf("/segments/%s/beta/uploadKeys", environmentID),
+		StatusCode: http.StatusServiceUnavailable,
+		Times:      1,
+	})
+
+	r := resourceSplitEnvironmentSegmentKeys()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	if got := fmt.Sprint(keyRequests(0, "/uploadKeys")); got != "[1000 1000 1000 500]" {
+		t.Fatalf("expected the keys to be uploaded in chunks with one retry, got %s", got)
+	}
+
+	// Keys are added with the same default title and comment as when they are updated or removed.
+	for _, req := range server.Requests() {
+		var body api.EnvironmentSegmentKeysRequest
+		if !strings.HasSuffix(req.Path, "/uploadKeys") {
+			continue
+		}
+		if err := json.Unmarshal([]byte(req.Body), &body); err != nil ||
+			body.Title != defaultSegmentKeysChange || body.Comment != defaultSegmentKeysChange {
+			t.Fatalf("expected the default title and comment, got %+v: %v", body, err)
+		}
+	}
+
+	// Every page of keys is read.
+	if got := d.Get("keys").(*schema.Set).Len(); got != 2500 {
+		t.Fatalf("expected 2500 keys to be read, got %d", got)
+	}
+
+	// Only the keys that changed are sent.
+	since := len(server.Requests())
+	raw["keys"] = keys(500, 3000)
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	state, diags := r.Apply(ctx, d.State(), diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	if got := fmt.Sprint(keyRequests(since, "/removeKeys")); got != "[500]" {
+		t.Fatalf("expected only the removed keys to be sent, got %s", got)
+	}
+	if got := fmt.Sprint(keyRequests(since, "/uploadKeys")); got != "[500]" {
+		t.Fatalf("expected only the added keys to be sent, got %s", got)
+	}
+
+	remaining, _, err := client.Environments.ListAllSegmentKeys(ctx, environmentID, "beta")
+	if err != nil || len(remaining) != 2500 || remaining[0] != "user-00500" {
+		t.Fatalf("unexpected segment keys: %d keys: %v", len(remaining), err)
+	}
+
+	// A failed update keeps the previous keys in state.
+	server.InjectFault(fakesplit.Fault{
+		Method:     http.MethodPut,
+		PathPrefix: fmt.Sprintf("/segments/%s/beta/uploadKeys", environmentID),
+		StatusCode: http.StatusBadRequest,
+		Times:      1,
+	})
+	raw["keys"] = keys(500, 3500)
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	failed, diags := r.Apply(ctx, state, diff, config)
+	if !diags.HasError() {
+		t.Fatal("expected the rejected request to fail")
+	}
+	if got := r.Data(failed).Get("keys").(*schema.Set).Len(); got != 2500 {
+		t.Fatalf("expected the previous keys to be kept in state, got %d", got)
+	}
+
+	// Requests that are rejected are not retried.
+	since = len(server.Requests())
+	server.InjectFault(fakesplit.Fault{
+		Method:     http.MethodPut,
+		PathPrefix: fmt.Sprintf("/segments/%s/beta/removeKeys", environmentID),
+		StatusCode: http.StatusBadRequest,
+		Times:      1,
+	})
+	if diags := r.DeleteContext(ctx, r.Data(state), config); !diags.HasError() {
+		t.Fatal("expected the rejected request to fail")
+	}
+	if got := len(keyRequests(since, "/removeKeys")); got != 1 {
+		t.Fatalf("expected the rejected request not to be retried, got %d requests", got)
+	}
+}
+
+func TestSplitEnvironmentSegmentKeys_ChunkFailure(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	environmentID := fixtures.Environment.GetID()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()),
+		api.RetryMaxAttempts(2), api.RetryBackoff(time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	if _, _, err := client.Segments.Create(ctx, fixtures.Workspace.GetID(), fixtures.TrafficType.GetID(), &api.SegmentRequest{Name: "beta"}); err != nil {
+		t.Fatalf("unable to create segment: %s", err)
+	}
+	if _, _, err := client.Segments.Activate(ctx, environmentID, "beta"); err != nil {
+		t.Fatalf("unable to activate segment: %s", err)
+	}
+
+	// A chunk that keeps failing is attempted only as many times as the client allows, and stops the next chunks.
+	server.InjectFault(fakesplit.Fault{
+		Method:     http.MethodPut,
+		PathPrefix: fmt.Sprintf("/segments/%s/beta/uploadKeys", environmentID),
+		StatusCode: http.StatusServiceUnavailable,
+		Times:      10,
+	})
+
+	r := resourceSplitEnvironmentSegmentKeys()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"environment_id": environmentID,
+		"segment_name":   "beta",
+		"keys":           []interface{}{"a", "b", "c"},
+		"chunk_size":     1,
+	})
+	if diags := r.CreateContext(ctx, d, config); !diags.HasError() {
+		t.Fatal("expected the failing chunk to fail")
+	}
+
+	uploads := 0
+	for _, req := range server.Requests() {
+		if strings.HasSuffix(req.Path, "/uploadKeys") {
+			uploads++
+		}
+	}
+	if uploads != 2 {
+		t.Fatalf("expected the first chunk to be attempted twice, got %d requests", uploads)
+	}
+
+	// Errors without a status code, such as network and decoding errors, are not retried either.
+	calls := 0
+	err = applySegmentKeysInChunks(ctx, d, []string{"a", "b"}, func(int, []string) error {
+		calls++
+		return errors.New("unexpected EOF")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected a single attempt, got %d attempts: %v", calls, err)
+	}
+
+	// No chunk is applied once the context is done.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	calls = 0
+	err = applySegmentKeysInChunks(cancelled, d, []string{"a", "b"}, func(int, []string) error {
+		calls++
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || calls != 0 {
+		t.Fatalf("expected no attempt, got %d attempts: %v", calls, err)
+	}
+}
+
+func TestSplitEnvironmentSegmentKeys_ChangeRequestKeyLimit(t *testing.T) {
+	keys := make([]interface{}, 0, api.MaxSegmentKeysPerRequest+1)
+	for i := 0; i <= api.MaxSegmentKeysPerRequest; i++ {
+		keys = append(keys, fmt.Sprintf("user-%05d", i))
+	}
+
+	raw := map[string]interface{}{
+		"environment_id": "b1b4a6a0-4d26-11ed-bdc3-0242ac120002",
+		"segment_name":   "beta",
+		"keys":           keys,
+	}
+
+	// Keys applied directly are sent in chunks.
+	if _, err := resourceSplitEnvironmentSegmentKeys().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	raw["change_request"] = []interface{}{
+		map[string]interface{}{"workspace_id": "a7cbd6a0-4d26-11ed-bdc3-0242ac120002"},
+	}
+	_, err := resourceSplitEnvironmentSegmentKeys().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	if err == nil || !strings.Contains(err.Error(), "limited to 10000 keys") {
+		t.Fatalf("expected the change request to be limited to 10000 keys, got: %v", err)
+	}
+
+	raw["keys"] = keys[:api.MaxSegmentKeysPerRequest]
+	if _, err := resourceSplitEnvironmentSegmentKeys().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestSplitEnvironmentSegmentKeys_DeleteWithChangeRequest(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	workspaceID := fixtures.Workspace.GetID()
+	environmentID := fixtures.Environment.GetID()
+	server.AutoResolveChangeRequests(api.ChangeRequestStatusApproved)
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	if _, _, err := client.Segments.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SegmentRequest{Name: "beta"}); err != nil {
+		t.Fatalf("unable to create segment: %s", err)
+	}
+	if _, _, err := client.Segments.Activate(ctx, environmentID, "beta"); err != nil {
+		t.Fatalf("unable to activate segment: %s", err)
+	}
+
+	r := resourceSplitEnvironmentSegmentKeys()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"environment_id": environmentID,
+		"segment_name":   "beta",
+		"keys":           []interface{}{"a", "b"},
+		"change_request": []interface{}{
+			map[string]interface{}{"workspace_id": workspaceID, "poll_interval": 1},
+		},
+	})
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	since := len(server.Requests())
+	if diags := r.DeleteContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	// The keys are removed through a change request proposing no keys rather than directly.
+	for _, req := range server.Requests()[since:] {
+		if strings.HasSuffix(req.Path, "/removeKeys") {
+			t.Fatalf("expected no direct removal, got %s %s", req.Method, req.Path)
+		}
+		if req.Method == http.MethodPost && !strings.Contains(req.Body, `"keys":[]`) {
+			t.Fatalf("expected a change request proposing no keys, got %s", req.Body)
+		}
+	}
+
+	keys, _, err := client.Environments.ListAllSegmentKeys(ctx, environmentID, "beta")
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("expected every key to be removed, got %v: %v", keys, err)
+	}
+}
+
 func testAccCheckSplitEnvironmentSegmentKeys_basic(
 	workspaceID, environmentName, trafficTypeName, segmentName string, production bool) string {
 	return fmt.Sprintf(`
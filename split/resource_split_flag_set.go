@@ -16,6 +16,8 @@ func resourceSplitFlagSet() *schema.Resource {
 		ReadContext:   resourceSplitFlagSetRead,
 		DeleteContext: resourceSplitFlagSetDelete,
 
+		Timeouts: defaultResourceTimeouts(false),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitFlagSetImport,
 		},
@@ -47,7 +49,7 @@ func resourceSplitFlagSet() *schema.Resource {
 func resourceSplitFlagSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Config).API
 
-	fs, _, getErr := client.FlagSets.FindByID(d.Id())
+	fs, _, getErr := client.FlagSets.FindByID(ctx, d.Id())
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -87,7 +89,7 @@ func resourceSplitFlagSetCreate(ctx context.Context, d *schema.ResourceData, met
 
 	log.Printf("[DEBUG] Creating Flag Set named %v", opts.GetName())
 
-	fs, _, createErr := client.FlagSets.Create(opts)
+	fs, _, createErr := client.FlagSets.Create(ctx, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -108,8 +110,14 @@ func resourceSplitFlagSetRead(ctx context.Context, d *schema.ResourceData, meta
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
 
-	fs, _, getErr := client.FlagSets.FindByID(d.Id())
+	fs, _, getErr := client.FlagSets.FindByID(ctx, d.Id())
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Flag set %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch flag set %s", d.Id()),
@@ -130,7 +138,7 @@ func resourceSplitFlagSetDelete(ctx context.Context, d *schema.ResourceData, met
 	client := meta.(*Config).API
 
 	log.Printf("[DEBUG] Deleting Flag Set %s", d.Id())
-	_, deleteErr := client.FlagSets.Delete(d.Id())
+	_, deleteErr := client.FlagSets.Delete(ctx, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
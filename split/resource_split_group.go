@@ -17,6 +17,8 @@ func resourceSplitGroup() *schema.Resource {
 		UpdateContext: resourceSplitGroupUpdate,
 		DeleteContext: resourceSplitGroupDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -53,7 +55,7 @@ func resourceSplitGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Creating group %s", opts.Name)
 
-	g, _, createErr := client.Groups.Create(opts)
+	g, _, createErr := client.Groups.Create(ctx, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -74,8 +76,14 @@ func resourceSplitGroupRead(ctx context.Context, d *schema.ResourceData, meta in
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
 
-	g, _, getErr := client.Groups.Get(d.Id())
+	g, _, getErr := client.Groups.Get(ctx, d.Id())
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Group %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch group %s", d.Id()),
@@ -107,7 +115,7 @@ func resourceSplitGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Updating group %s", d.Id())
 
-	_, _, updateErr := client.Groups.Update(d.Id(), opts)
+	_, _, updateErr := client.Groups.Update(ctx, d.Id(), opts)
 	if updateErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -128,7 +136,7 @@ func resourceSplitGroupDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Deleting group %s", d.Id())
 
-	_, deleteErr := client.Groups.Delete(d.Id())
+	_, deleteErr := client.Groups.Delete(ctx, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -0,0 +1,266 @@
+package split
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSplitGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSplitGroupMembershipCreate,
+		ReadContext:   resourceSplitGroupMembershipRead,
+		UpdateContext: resourceSplitGroupMembershipUpdate,
+		DeleteContext: resourceSplitGroupMembershipDelete,
+
+		Timeouts: defaultResourceTimeouts(true),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSplitGroupMembershipImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceSplitGroupMembershipImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Config).API
+
+	g, _, getErr := client.Groups.Get(ctx, d.Id())
+	if getErr != nil {
+		return nil, getErr
+	}
+
+	memberIDs, listErr := listGroupMemberIDs(ctx, client, g.GetID())
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	d.SetId(g.GetID())
+	d.Set("group_id", g.GetID())
+	d.Set("user_ids", memberIDs)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSplitGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	groupID := d.Get("group_id").(string)
+
+	log.Printf("[DEBUG] Setting members of group %s", groupID)
+
+	if diags := reconcileGroupMembership(ctx, d, meta.(*Config).API, groupID); diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Set members of group %s", groupID)
+
+	d.SetId(groupID)
+
+	return resourceSplitGroupMembershipRead(ctx, d, meta)
+}
+
+func resourceSplitGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*Config).API
+
+	if _, _, getErr := client.Groups.Get(ctx, d.Id()); getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Group %s not found, removing membership from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to fetch group %v", d.Id()),
+			Detail:   getErr.Error(),
+		})
+		return diags
+	}
+
+	memberIDs, listErr := listGroupMemberIDs(ctx, client, d.Id())
+	if listErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to list members of group %v", d.Id()),
+			Detail:   listErr.Error(),
+		})
+		return diags
+	}
+
+	d.Set("group_id", d.Id())
+	d.Set("user_ids", memberIDs)
+
+	return diags
+}
+
+func resourceSplitGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if ok := d.HasChange("user_ids"); ok {
+		log.Printf("[DEBUG] Updating members of group %s", d.Id())
+
+		if diags := reconcileGroupMembership(ctx, d, meta.(*Config).API, d.Id()); diags.HasError() {
+			return diags
+		}
+
+		log.Printf("[DEBUG] Updated members of group %s", d.Id())
+	}
+
+	return resourceSplitGroupMembershipRead(ctx, d, meta)
+}
+
+func resourceSplitGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*Config).API
+
+	log.Printf("[DEBUG] Removing all members of group %s", d.Id())
+
+	for _, userID := range setToStringSlice(d.Get("user_ids").(*schema.Set)) {
+		if removeErr := setUserGroupMembership(ctx, client, userID, d.Id(), false); removeErr != nil {
+			if api.IsNotFound(removeErr) {
+				continue
+			}
+
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to remove user %v from group %v", userID, d.Id()),
+				Detail:   removeErr.Error(),
+			})
+			return diags
+		}
+	}
+
+	log.Printf("[DEBUG] Removed all members of group %s", d.Id())
+
+	d.SetId("")
+
+	return diags
+}
+
+// reconcileGroupMembership adds the configured users missing from the group and removes the members
+// that are not configured.
+func reconcileGroupMembership(ctx context.Context, d *schema.ResourceData, client *api.Client, groupID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	memberIDs, listErr := listGroupMemberIDs(ctx, client, groupID)
+	if listErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to list members of group %v", groupID),
+			Detail:   listErr.Error(),
+		})
+		return diags
+	}
+
+	userIDs := setToStringSlice(d.Get("user_ids").(*schema.Set))
+
+	for _, userID := range userIDs {
+		if slices.Contains(memberIDs, userID) {
+			continue
+		}
+
+		log.Printf("[DEBUG] Adding user %s to group %s", userID, groupID)
+
+		if addErr := setUserGroupMembership(ctx, client, userID, groupID, true); addErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to add user %v to group %v", userID, groupID),
+				Detail:   addErr.Error(),
+			})
+			return diags
+		}
+	}
+
+	for _, memberID := range memberIDs {
+		if slices.Contains(userIDs, memberID) {
+			continue
+		}
+
+		log.Printf("[DEBUG] Removing user %s from group %s", memberID, groupID)
+
+		if removeErr := setUserGroupMembership(ctx, client, memberID, groupID, false); removeErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to remove user %v from group %v", memberID, groupID),
+				Detail:   removeErr.Error(),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// listGroupMemberIDs returns the IDs of every active or invited member of a group, following pagination.
+func listGroupMemberIDs(ctx context.Context, client *api.Client, groupID string) ([]string, error) {
+	memberIDs := make([]string, 0)
+
+	// Users are listed by status, which only includes active users unless set, so invited users that have not
+	// accepted their invitation yet are listed separately.
+	for _, status := range []string{api.UserStatusActive, api.UserStatusPending} {
+		members, _, listErr := client.Users.ListAll(ctx, &api.UserListOpts{Status: status, GroupID: groupID})
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		for _, u := range members {
+			memberIDs = append(memberIDs, u.GetID())
+		}
+	}
+
+	return memberIDs, nil
+}
+
+// setUserGroupMembership adds a user to, or removes a user from, a group while keeping the user's other groups.
+func setUserGroupMembership(ctx context.Context, client *api.Client, userID, groupID string, member bool) error {
+	u, _, getErr := client.Users.Get(ctx, userID)
+	if getErr != nil {
+		return getErr
+	}
+
+	groupIDs := slices.DeleteFunc(u.GroupIDs(), func(id string) bool { return id == groupID })
+	if member {
+		groupIDs = append(groupIDs, groupID)
+	}
+
+	_, _, updateErr := client.Users.UpdateUserGroups(ctx, userID, groupIDs)
+
+	return updateErr
+}
+
+// resourceSplitGroupMembershipWithDeprecation wraps resourceSplitGroupMembership and adds plan-time deprecation checks for harness_token
+func resourceSplitGroupMembershipWithDeprecation() *schema.Resource {
+	r := resourceSplitGroupMembership()
+
+	// Add plan-time validation using CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		// Detect harness_token from provider config OR environment
+		tokenSet := isHarnessTokenSet(meta)
+
+		// If token is set, show deprecation error during plan
+		if tokenSet {
+			return fmt.Errorf("resource split_group_membership cannot be used when harness_token is set: the resource split_group_membership is deprecated when using harness_token for authentication, please use the harness terraform provider instead")
+		}
+		return nil
+	}
+
+	return r
+}
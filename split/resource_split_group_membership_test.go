@@ -0,0 +1,174 @@
+package split
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSplitGroupMembership_Basic(t *testing.T) {
+	// Skip test if using harness_token as this resource is deprecated with harness_token
+	skipIfUsingHarnessToken(t, "split_group_membership")
+
+	email := testAccConfig.GetUserEmailorSkip(t)
+	emailSplit := strings.Split(email, "@")
+	emailFormatted := fmt.Sprintf("%s+%s@%s", emailSplit[0], acctest.RandString(8), emailSplit[1])
+	groupName := fmt.Sprintf("tftest-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitGroupMembership_basic(groupName, emailFormatted),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"split_group_membership.foobar", "group_id", "split_group.foobar", "id"),
+					resource.TestCheckResourceAttr(
+						"split_group_membership.foobar", "user_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitGroupMembership_Lifecycle(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	group, _, err := client.Groups.Create(ctx, &api.GroupRequest{Name: "developers"})
+	if err != nil {
+		t.Fatalf("unable to create group: %s", err)
+	}
+	other, _, err := client.Groups.Create(ctx, &api.GroupRequest{Name: "admins"})
+	if err != nil {
+		t.Fatalf("unable to create group: %s", err)
+	}
+
+	// Active users, and enough invited users pending their invitation to span several pages of the users list.
+	userIDs := make([]interface{}, 0)
+	for i := 0; i < 3; i++ {
+		u := server.AddUser(fmt.Sprintf("active%d@example.com", i), api.UserStatusActive)
+		if _, _, err := client.Users.UpdateUserGroups(ctx, u.GetID(), []string{other.GetID()}); err != nil {
+			t.Fatalf("unable to update user groups: %s", err)
+		}
+		userIDs = append(userIDs, u.GetID())
+	}
+	for i := 0; i < api.DefaultMarkerPageSize+50; i++ {
+		u, _, err := client.Users.Invite(ctx, &api.UserCreateRequest{Email: fmt.Sprintf("user%d@example.com", i), Groups: api.NewUserGroupRefs([]string{other.GetID()})})
+		if err != nil {
+			t.Fatalf("unable to invite user: %s", err)
+		}
+		userIDs = append(userIDs, u.GetID())
+	}
+
+	// An unexpected member is removed on create.
+	if _, _, err := client.Users.UpdateUserGroups(ctx, userIDs[0].(string), []string{group.GetID(), other.GetID()}); err != nil {
+		t.Fatalf("unable to update user groups: %s", err)
+	}
+
+	raw := map[string]interface{}{
+		"group_id": group.GetID(),
+		"user_ids": userIDs[1:],
+	}
+
+	r := resourceSplitGroupMembership()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	expectMembers := func(want int) {
+		t.Helper()
+		members, err := listGroupMemberIDs(ctx, client, group.GetID())
+		if err != nil {
+			t.Fatalf("unable to list group members: %s", err)
+		}
+		if len(members) != want {
+			t.Fatalf("expected %d members, got %d", want, len(members))
+		}
+	}
+
+	expectMembers(len(userIDs) - 1)
+	if d.Get("user_ids").(*schema.Set).Len() != len(userIDs)-1 {
+		t.Fatalf("expected every member to be read, got %d", d.Get("user_ids").(*schema.Set).Len())
+	}
+
+	// Invited users are read back like active ones, so the plan is empty.
+	if diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config); err != nil || diff != nil {
+		t.Fatalf("expected no diff, got %+v: %v", diff, err)
+	}
+
+	// Removing a user from the group keeps the user's other groups.
+	u, _, err := client.Users.Get(ctx, userIDs[0].(string))
+	if err != nil || fmt.Sprint(u.GroupIDs()) != fmt.Sprint([]string{other.GetID()}) {
+		t.Fatalf("expected the user to only be removed from the group, got %+v: %v", u, err)
+	}
+
+	// Members changed outside of Terraform are detected on read and reverted on apply.
+	if _, _, err := client.Users.UpdateUserGroups(ctx, userIDs[1].(string), nil); err != nil {
+		t.Fatalf("unable to update user groups: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.RequiresNew() {
+		t.Fatalf("expected an in-place update, got %+v", diff)
+	}
+
+	state, diags := r.Apply(ctx, d.State(), diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectMembers(len(userIDs) - 1)
+
+	// Destroying the resource removes every member from the group.
+	d = r.Data(state)
+	if diags := r.DeleteContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectMembers(0)
+}
+
+func testAccCheckSplitGroupMembership_basic(groupName, email string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "split_group" "foobar" {
+	name = "%s"
+}
+
+resource "split_user" "foobar" {
+	email = "%s"
+
+	lifecycle {
+		ignore_changes = [group_ids]
+	}
+}
+
+resource "split_group_membership" "foobar" {
+	group_id = split_group.foobar.id
+	user_ids = [split_user.foobar.id]
+}
+`, testAccGetProviderConfig(), groupName, email)
+}
@@ -0,0 +1,376 @@
+package split
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSplitProgressiveRollout() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSplitProgressiveRolloutCreate,
+		ReadContext:   resourceSplitProgressiveRolloutRead,
+		UpdateContext: resourceSplitProgressiveRolloutUpdate,
+		DeleteContext: resourceSplitProgressiveRolloutDelete,
+
+		Timeouts: defaultResourceTimeouts(true),
+
+		CustomizeDiff: resourceSplitProgressiveRolloutCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"split_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"environment_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"treatment": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"baseline_treatment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"steps": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 100),
+				},
+			},
+
+			"dwell_time": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDuration,
+			},
+
+			"paused": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"aborted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"current_step": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"current_step_started_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"current_percentage": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// validateDuration checks that the value can be parsed by time.ParseDuration, for example "24h".
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// resourceSplitProgressiveRolloutCustomizeDiff validates the steps and plans the next step of the rollout
+// once the dwell time of the current step has passed.
+func resourceSplitProgressiveRolloutCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := validateProgressiveRollout(diff); err != nil {
+		return err
+	}
+
+	if err := diffProgressiveRollout(diff, time.Now()); err != nil {
+		return err
+	}
+
+	return validateSplitDefinitionChangeNotes(ctx, diff, meta)
+}
+
+// validateProgressiveRollout checks that the steps increase and that the ramped treatment is not the baseline.
+func validateProgressiveRollout(d *schema.ResourceDiff) error {
+	if d.NewValueKnown("steps") {
+		steps := progressiveRolloutSteps(d)
+		for i := 1; i < len(steps); i++ {
+			if steps[i] <= steps[i-1] {
+				return fmt.Errorf("steps must increase, got %d after %d", steps[i], steps[i-1])
+			}
+		}
+	}
+
+	if d.NewValueKnown("treatment") && d.NewValueKnown("baseline_treatment") &&
+		d.Get("treatment").(string) == d.Get("baseline_treatment").(string) {
+		return fmt.Errorf("treatment and baseline_treatment must differ, both are %q", d.Get("treatment"))
+	}
+
+	return nil
+}
+
+// diffProgressiveRollout plans the percentage the rollout should serve. The rollout advances by one step when the
+// dwell time of the current step has passed, and the percentage of the current step is restored when it was
+// changed outside of Terraform.
+func diffProgressiveRollout(d *schema.ResourceDiff, now time.Time) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if !d.NewValueKnown("steps") || !d.NewValueKnown("dwell_time") {
+		return d.SetNewComputed("current_percentage")
+	}
+
+	steps := progressiveRolloutSteps(d)
+	step := min(d.Get("current_step").(int), len(steps)-1)
+
+	if d.Get("aborted").(bool) {
+		return setNewIfChanged(d, "current_percentage", 0)
+	}
+
+	startedAt, _ := time.Parse(time.RFC3339, d.Get("current_step_started_at").(string))
+	dwellTime, _ := time.ParseDuration(d.Get("dwell_time").(string))
+
+	if !d.Get("paused").(bool) && step < len(steps)-1 && !now.Before(startedAt.Add(dwellTime)) {
+		step++
+		log.Printf("[DEBUG] Dwell time of step %d has passed, advancing rollout to step %d", step-1, step)
+
+		if err := d.SetNew("current_step", step); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed("current_step_started_at"); err != nil {
+			return err
+		}
+	}
+
+	return setNewIfChanged(d, "current_percentage", steps[step])
+}
+
+// setNewIfChanged sets the planned value of a computed attribute when it differs from the current value.
+func setNewIfChanged(d *schema.ResourceDiff, key string, value interface{}) error {
+	if d.Get(key) == value {
+		return nil
+	}
+	return d.SetNew(key, value)
+}
+
+// progressiveRolloutSteps returns the percentages of every step of the rollout.
+func progressiveRolloutSteps(d resourceGetter) []int {
+	steps := make([]int, 0)
+	for _, v := range d.Get("steps").([]interface{}) {
+		steps = append(steps, v.(int))
+	}
+	return steps
+}
+
+// constructProgressiveRolloutDefaultRule returns the default rule serving percentage of traffic the ramped
+// treatment and the rest the baseline treatment.
+func constructProgressiveRolloutDefaultRule(treatment, baselineTreatment string, percentage int) []api.Bucket {
+	buckets := make([]api.Bucket, 0)
+
+	if percentage > 0 {
+		size := percentage
+		buckets = append(buckets, api.Bucket{Treatment: &treatment, Size: &size})
+	}
+
+	if percentage < 100 {
+		size := 100 - percentage
+		buckets = append(buckets, api.Bucket{Treatment: &baselineTreatment, Size: &size})
+	}
+
+	return buckets
+}
+
+// progressiveRolloutPercentage returns the percentage of the default rule serving the treatment.
+func progressiveRolloutPercentage(sd *api.SplitDefinition, treatment string) int {
+	percentage := 0
+	for _, b := range sd.DefaultRule {
+		if b.GetTreatment() == treatment {
+			percentage += b.GetSize()
+		}
+	}
+	return percentage
+}
+
+func resourceSplitProgressiveRolloutCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	percentage := progressiveRolloutSteps(d)[0]
+	if d.Get("aborted").(bool) {
+		percentage = 0
+	}
+
+	sd, diags := setProgressiveRolloutPercentage(ctx, d, meta.(*Config), percentage)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(sd.GetID())
+	d.Set("current_step", 0)
+	d.Set("current_step_started_at", time.Now().UTC().Format(time.RFC3339))
+
+	return resourceSplitProgressiveRolloutRead(ctx, d, meta)
+}
+
+func resourceSplitProgressiveRolloutUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	steps := progressiveRolloutSteps(d)
+	step := min(d.Get("current_step").(int), len(steps)-1)
+
+	percentage := steps[step]
+	if d.Get("aborted").(bool) {
+		percentage = 0
+	}
+
+	// Changes that do not affect the served percentage do not require updating the definition.
+	if d.HasChanges("current_percentage", "treatment", "baseline_treatment") {
+		if _, diags := setProgressiveRolloutPercentage(ctx, d, meta.(*Config), percentage); diags.HasError() {
+			return diags
+		}
+	}
+
+	d.Set("current_step", step)
+	if d.HasChange("current_step") {
+		d.Set("current_step_started_at", time.Now().UTC().Format(time.RFC3339))
+	}
+
+	return resourceSplitProgressiveRolloutRead(ctx, d, meta)
+}
+
+// setProgressiveRolloutPercentage replaces the default rule of the split definition so that it serves the given
+// percentage of traffic the ramped treatment.
+func setProgressiveRolloutPercentage(ctx context.Context, d *schema.ResourceData, config *Config, percentage int) (*api.SplitDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	client := config.API
+
+	workspaceID := getWorkspaceID(d)
+	splitName := getSplitName(d)
+	environmentID := getEnvironmentID(d)
+	treatment := d.Get("treatment").(string)
+
+	current, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, environmentID)
+	if getErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to fetch the definition of split %v", splitName),
+			Detail:   getErr.Error(),
+		})
+		return nil, diags
+	}
+
+	baselineTreatment := d.Get("baseline_treatment").(string)
+	if baselineTreatment == "" {
+		baselineTreatment = current.GetDefaultTreatment()
+	}
+	if baselineTreatment == treatment {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to roll out treatment %v of split %v", treatment, splitName),
+			Detail:   "The treatment is the default treatment of the split definition. Set baseline_treatment to the treatment serving the rest of the traffic.",
+		})
+		return nil, diags
+	}
+
+	opts := splitDefinitionRequestFromDefinition(current)
+	opts.DefaultRule = constructProgressiveRolloutDefaultRule(treatment, baselineTreatment, percentage)
+	opts.Title, opts.Comment = splitDefinitionChangeTitleAndComment(d, config)
+
+	log.Printf("[DEBUG] Rolling out treatment %v of split [%v] to %d%%", treatment, splitName, percentage)
+
+	sd, _, updateErr := client.Splits.UpdateDefinitionFull(ctx, workspaceID, splitName, environmentID, opts)
+	if updateErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to roll out treatment %v of split %v", treatment, splitName),
+			Detail:   updateErr.Error(),
+		})
+		return nil, diags
+	}
+
+	log.Printf("[DEBUG] Rolled out treatment %v of split [%v] to %d%%", treatment, splitName, percentage)
+
+	return sd, diags
+}
+
+func resourceSplitProgressiveRolloutRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*Config).API
+
+	workspaceID := getWorkspaceID(d)
+	splitName := getSplitName(d)
+	environmentID := getEnvironmentID(d)
+
+	sd, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, environmentID)
+	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Split definition %s of progressive rollout not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to fetch split definition %s", d.Id()),
+			Detail:   getErr.Error(),
+		})
+		return diags
+	}
+
+	d.Set("workspace_id", workspaceID)
+	d.Set("split_name", sd.GetName())
+	d.Set("environment_id", sd.GetEnvironment().GetID())
+	d.Set("current_percentage", progressiveRolloutPercentage(sd, d.Get("treatment").(string)))
+
+	return diags
+}
+
+// resourceSplitProgressiveRolloutDelete only removes the rollout from state. The split definition keeps serving
+// the percentage of the last step.
+func resourceSplitProgressiveRolloutDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	log.Printf("[DEBUG] Removing progressive rollout %s from state", d.Id())
+
+	d.SetId("")
+
+	return diags
+}
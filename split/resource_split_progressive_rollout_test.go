@@ -0,0 +1,260 @@
+package split
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSplitProgressiveRollout_Basic(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitProgressiveRollout_basic(workspaceID, splitName, envID, trafficTypeID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_progressive_rollout.foobar", "current_step", "0"),
+					resource.TestCheckResourceAttr(
+						"split_progressive_rollout.foobar", "current_percentage", "1"),
+					resource.TestCheckResourceAttrSet(
+						"split_progressive_rollout.foobar", "current_step_started_at"),
+				),
+			},
+			{
+				Config: testAccCheckSplitProgressiveRollout_basic(workspaceID, splitName, envID, trafficTypeID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_progressive_rollout.foobar", "current_percentage", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitProgressiveRollout_Lifecycle(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	workspaceID := fixtures.Workspace.GetID()
+	environmentID := fixtures.Environment.GetID()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+
+	ctx := context.Background()
+	if _, _, err := client.Splits.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SplitCreateRequest{Name: "foobar"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	definition, err := expandSplitDefinitionJSON(testSplitDefinitionJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := client.Splits.CreateDefinition(ctx, workspaceID, "foobar", environmentID, definition); err != nil {
+		t.Fatalf("unable to create definition: %s", err)
+	}
+
+	raw := map[string]interface{}{
+		"workspace_id":   workspaceID,
+		"split_name":     "foobar",
+		"environment_id": environmentID,
+		"treatment":      "on",
+		"steps":          []interface{}{10, 50, 100},
+		"dwell_time":     "1h",
+	}
+
+	r := resourceSplitProgressiveRollout()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	expectPercentage := func(want int) {
+		t.Helper()
+		sd, _, err := client.Splits.GetDefinition(ctx, workspaceID, "foobar", environmentID)
+		if err != nil {
+			t.Fatalf("unable to fetch definition: %s", err)
+		}
+		if got := progressiveRolloutPercentage(sd, "on"); got != want {
+			t.Fatalf("expected %d%% of traffic to be served on, got %d%%: %+v", want, got, sd.DefaultRule)
+		}
+		if want < 100 && progressiveRolloutPercentage(sd, "off") != 100-want {
+			t.Fatalf("expected the rest of the traffic to be served off, got %+v", sd.DefaultRule)
+		}
+	}
+
+	plan := func() *terraform.InstanceDiff {
+		t.Helper()
+		diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return diff
+	}
+
+	apply := func(diff *terraform.InstanceDiff) {
+		t.Helper()
+		state, diags := r.Apply(ctx, d.State(), diff, config)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %+v", diags)
+		}
+		d = r.Data(state)
+	}
+
+	expectPercentage(10)
+	if diff := plan(); diff != nil && len(diff.Attributes) > 0 {
+		t.Fatalf("expected no changes before the dwell time has passed, got %+v", diff.Attributes)
+	}
+
+	// Once the dwell time has passed the rollout advances by one step.
+	d.Set("current_step_started_at", time.Now().Add(-2*time.Hour).UTC().Format(time.RFC3339))
+	diff := plan()
+	if diff == nil || diff.Attributes["current_step"] == nil || diff.Attributes["current_step"].New != "1" {
+		t.Fatalf("expected the next step to be planned, got %+v", diff)
+	}
+	apply(diff)
+	expectPercentage(50)
+	if d.Get("current_step").(int) != 1 || d.Get("current_percentage").(int) != 50 {
+		t.Fatalf("unexpected state: step %v, percentage %v", d.Get("current_step"), d.Get("current_percentage"))
+	}
+
+	// Paused rollouts do not advance.
+	raw["paused"] = true
+	d.Set("current_step_started_at", time.Now().Add(-2*time.Hour).UTC().Format(time.RFC3339))
+	if diff := plan(); diff != nil && diff.Attributes["current_step"] != nil {
+		t.Fatalf("expected a paused rollout not to advance, got %+v", diff.Attributes)
+	}
+
+	// Changes made outside of Terraform are reverted to the current step.
+	definition.DefaultRule = constructProgressiveRolloutDefaultRule("on", "off", 0)
+	if _, _, err := client.Splits.UpdateDefinitionFull(ctx, workspaceID, "foobar", environmentID, definition); err != nil {
+		t.Fatalf("unable to update definition: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	apply(plan())
+	expectPercentage(50)
+
+	// Aborted rollouts serve the baseline treatment to all traffic.
+	raw["aborted"] = true
+	apply(plan())
+	expectPercentage(0)
+}
+
+func testAccCheckSplitProgressiveRollout_basic(workspaceID, splitName, envID, trafficTypeID string, aborted bool) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s"
+}
+
+resource "split_split_definition" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	environment_id = "%[3]s"
+
+	default_treatment = "off"
+	treatment {
+		name = "on"
+		configurations = "{}"
+	}
+	treatment {
+		name = "off"
+		configurations = "{}"
+	}
+
+	default_rule {
+		treatment = "off"
+		size = 100
+	}
+
+	lifecycle {
+		ignore_changes = [default_rule]
+	}
+}
+
+resource "split_progressive_rollout" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split_definition.foobar.split_name
+	environment_id = split_split_definition.foobar.environment_id
+	treatment = "on"
+	steps = [1, 5, 25, 50, 100]
+	dwell_time = "24h"
+	aborted = %[5]t
+}
+`, workspaceID, splitName, envID, trafficTypeID, aborted)
+}
+
+func TestSplitProgressiveRollout_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  map[string]interface{}
+		err  string
+	}{
+		{
+			name: "valid",
+			raw:  map[string]interface{}{"steps": []interface{}{1, 50, 100}},
+		},
+		{
+			name: "decreasing steps",
+			raw:  map[string]interface{}{"steps": []interface{}{50, 10}},
+			err:  "steps must increase, got 10 after 50",
+		},
+		{
+			name: "baseline is the ramped treatment",
+			raw:  map[string]interface{}{"steps": []interface{}{50}, "baseline_treatment": "on"},
+			err:  `treatment and baseline_treatment must differ, both are "on"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"workspace_id":   "a7cbd6a0-4d26-11ed-bdc3-0242ac120002",
+				"split_name":     "foobar",
+				"environment_id": "b1b4a6a0-4d26-11ed-bdc3-0242ac120002",
+				"treatment":      "on",
+				"dwell_time":     "24h",
+			}
+			for k, v := range tc.raw {
+				raw[k] = v
+			}
+
+			_, err := resourceSplitProgressiveRollout().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("expected error %q, got: %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -14,8 +14,13 @@ func resourceSplitSegment() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSplitSegmentCreate,
 		ReadContext:   resourceSplitSegmentRead,
+		UpdateContext: resourceSplitSegmentUpdate,
 		DeleteContext: resourceSplitSegmentDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
+		CustomizeDiff: resourceSplitSegmentCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitSegmentImport,
 		},
@@ -45,12 +50,41 @@ func resourceSplitSegment() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
-				ForceNew: true,
+			},
+
+			"tags": tagsSchema(),
+
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 		},
 	}
 }
 
+// resourceSplitSegmentCustomizeDiff rejects changes to the description of an existing segment, as the Split API has
+// no public endpoint to update it and recreating the segment removes its keys from every environment. A title and
+// comment are only required when creating the segment, as tags are updated through an endpoint that accepts neither.
+func resourceSplitSegmentCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return validateChangeTitleAndComment(ctx, diff, meta)
+	}
+
+	if diff.HasChange("description") {
+		o, n := diff.GetChange("description")
+		return fmt.Errorf("the description of segment %s cannot be changed from %q to %q: the Split API does not "+
+			"support updating it, and recreating the segment would remove its keys from every environment. "+
+			"Revert the description, or replace the segment explicitly with `terraform apply -replace`", diff.Id(), o, n)
+	}
+
+	return nil
+}
+
 func resourceSplitSegmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Config).API
 
@@ -62,7 +96,7 @@ func resourceSplitSegmentImport(ctx context.Context, d *schema.ResourceData, met
 	workspaceID := importID[0]
 	segmentName := importID[1]
 
-	s, _, getErr := client.Segments.Get(workspaceID, segmentName)
+	s, _, getErr := client.Segments.Get(ctx, workspaceID, segmentName)
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -72,13 +106,15 @@ func resourceSplitSegmentImport(ctx context.Context, d *schema.ResourceData, met
 	d.Set("traffic_type_id", s.GetTrafficType().GetID())
 	d.Set("name", s.GetName())
 	d.Set("description", s.GetDescription())
+	d.Set("tags", s.TagNames())
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceSplitSegmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 	opts := &api.SegmentRequest{}
 	workspaceID := getWorkspaceID(d)
 
@@ -98,9 +134,11 @@ func resourceSplitSegmentCreate(ctx context.Context, d *schema.ResourceData, met
 		log.Printf("[DEBUG] new segment description is : %v", opts.Description)
 	}
 
+	opts.Title, opts.Comment = changeTitleAndComment(d, config)
+
 	log.Printf("[DEBUG] Creating segment %s", opts.Name)
 
-	s, _, createErr := client.Segments.Create(workspaceID, trafficTypeID, opts)
+	s, _, createErr := client.Segments.Create(ctx, workspaceID, trafficTypeID, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -114,6 +152,46 @@ func resourceSplitSegmentCreate(ctx context.Context, d *schema.ResourceData, met
 
 	d.SetId(s.GetName())
 
+	if tags := getTags(d); len(tags) > 0 {
+		log.Printf("[DEBUG] Tagging segment %s", opts.Name)
+
+		if _, tagErr := client.Segments.UpdateTags(ctx, workspaceID, s.GetName(), tags); tagErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to tag segment %v", opts.Name),
+				Detail:   tagErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Tagged segment %s", opts.Name)
+	}
+
+	return resourceSplitSegmentRead(ctx, d, meta)
+}
+
+func resourceSplitSegmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*Config).API
+	workspaceID := getWorkspaceID(d)
+
+	if ok := d.HasChange("tags"); ok {
+		tags := getTags(d)
+		log.Printf("[DEBUG] Updating segment tags %s: %v", d.Id(), tags)
+
+		_, updateErr := client.Segments.UpdateTags(ctx, workspaceID, d.Id(), tags)
+		if updateErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to update segment tags %s", d.Id()),
+				Detail:   updateErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Updated segment tags %s", d.Id())
+	}
+
 	return resourceSplitSegmentRead(ctx, d, meta)
 }
 
@@ -122,8 +200,14 @@ func resourceSplitSegmentRead(ctx context.Context, d *schema.ResourceData, meta
 	client := meta.(*Config).API
 	workspaceID := getWorkspaceID(d)
 
-	s, _, getErr := client.Segments.Get(workspaceID, d.Id())
+	s, _, getErr := client.Segments.Get(ctx, workspaceID, d.Id())
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Segment %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch segment %s", d.Id()),
@@ -136,6 +220,7 @@ func resourceSplitSegmentRead(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("traffic_type_id", s.GetTrafficType().GetID())
 	d.Set("name", s.GetName())
 	d.Set("description", s.GetDescription())
+	d.Set("tags", s.TagNames())
 
 	return diags
 }
@@ -147,7 +232,7 @@ func resourceSplitSegmentDelete(ctx context.Context, d *schema.ResourceData, met
 
 	log.Printf("[DEBUG] Deleting segment %s", d.Id())
 
-	_, deleteErr := client.Segments.Delete(workspaceID, d.Id())
+	_, deleteErr := client.Segments.Delete(ctx, workspaceID, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
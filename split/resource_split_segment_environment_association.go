@@ -16,6 +16,8 @@ func resourceSplitSegmentEnvironmentAssociation() *schema.Resource {
 		ReadContext:   resourceSplitSegmentEnvironmentAssociationRead,
 		DeleteContext: resourceSplitSegmentEnvironmentAssociationDelete,
 
+		Timeouts: defaultResourceTimeouts(false),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitSegmentEnvironmentAssociationImport,
 		},
@@ -56,14 +58,14 @@ func resourceSplitSegmentEnvironmentAssociationImport(ctx context.Context, d *sc
 	environmentID := importID[1]
 	segmentName := importID[2]
 
-	segments, _, getErr := client.Environments.ListSegments(workspaceID, environmentID)
+	segments, _, getErr := client.Environments.ListAllSegments(ctx, workspaceID, environmentID)
 	if getErr != nil {
 		return nil, fmt.Errorf(fmt.Sprintf("unable to fetch all segments in environment %s", environmentID))
 	}
 
 	// Iterate through all segments to find the right one
 	var segment *api.Segment
-	for _, s := range segments.Objects {
+	for _, s := range segments {
 		if s.GetName() == segmentName {
 			segment = s
 		}
@@ -88,7 +90,7 @@ func resourceSplitSegmentEnvironmentAssociationCreate(ctx context.Context, d *sc
 
 	log.Printf("[DEBUG] Activating segment [%s] in environment [%s]", segmentName, environmentID)
 
-	s, _, createErr := client.Segments.Activate(environmentID, segmentName)
+	s, _, createErr := client.Segments.Activate(ctx, environmentID, segmentName)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -111,8 +113,14 @@ func resourceSplitSegmentEnvironmentAssociationRead(ctx context.Context, d *sche
 	workspaceID := getWorkspaceID(d)
 	environmentID := getEnvironmentID(d)
 
-	segments, _, getErr := client.Environments.ListSegments(workspaceID, environmentID)
+	segments, _, getErr := client.Environments.ListAllSegments(ctx, workspaceID, environmentID)
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Environment %s not found, removing segment environment association %s from state", environmentID, d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch all segments in environment %s", environmentID),
@@ -123,18 +131,15 @@ func resourceSplitSegmentEnvironmentAssociationRead(ctx context.Context, d *sche
 
 	// Iterate through all segments to find the right one
 	var segment *api.Segment
-	for _, s := range segments.Objects {
+	for _, s := range segments {
 		if s.GetName() == d.Id() {
 			segment = s
 		}
 	}
 
 	if segment == nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("did not find to segment [%s] in environment [%s]", d.Id(), environmentID),
-			Detail:   getErr.Error(),
-		})
+		log.Printf("[WARN] Segment [%s] not found in environment [%s], removing from state", d.Id(), environmentID)
+		d.SetId("")
 		return diags
 	}
 
@@ -152,7 +157,7 @@ func resourceSplitSegmentEnvironmentAssociationDelete(ctx context.Context, d *sc
 
 	log.Printf("[DEBUG] Deactivating segment [%s] from environment [%s]", d.Id(), environmentID)
 
-	_, deleteErr := client.Segments.Deactivate(environmentID, d.Id())
+	_, deleteErr := client.Segments.Deactivate(ctx, environmentID, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -1,9 +1,16 @@
 package split
 
 import (
+	"context"
 	"fmt"
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -17,7 +24,7 @@ func TestAccSplitSegment_Basic(t *testing.T) {
 		ProviderFactories: testAccProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, "created from Terraform"),
+				Config: testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, "created from Terraform", "team-a"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(
 						"split_segment.foobar", "workspace_id", workspaceID),
@@ -27,19 +34,143 @@ func TestAccSplitSegment_Basic(t *testing.T) {
 						"split_segment.foobar", "name", name),
 					resource.TestCheckResourceAttr(
 						"split_segment.foobar", "description", "created from Terraform"),
+					resource.TestCheckResourceAttr(
+						"split_segment.foobar", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(
+						"split_segment.foobar", "tags.*", "team-a"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, "created from Terraform", "team-b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_segment.foobar", "name", name),
+					resource.TestCheckResourceAttr(
+						"split_segment.foobar", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(
+						"split_segment.foobar", "tags.*", "team-b"),
 				),
 			},
+			{
+				Config:      testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, "edited from Terraform", "team-b"),
+				ExpectError: regexp.MustCompile("cannot be changed"),
+			},
 		},
 	})
 }
 
-func testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, description string) string {
+func TestSplitSegment_UpdateInPlace(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	workspaceID := fixtures.Workspace.GetID()
+	environmentID := fixtures.Environment.GetID()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	raw := map[string]interface{}{
+		"workspace_id":    workspaceID,
+		"traffic_type_id": fixtures.TrafficType.GetID(),
+		"name":            "beta",
+		"description":     "created from Terraform",
+		"tags":            []interface{}{"team-a"},
+		"title":           "checkout beta",
+		"comment":         "targets the beta testers",
+	}
+
+	r := resourceSplitSegment()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	if _, _, err := client.Segments.Activate(ctx, environmentID, "beta"); err != nil {
+		t.Fatalf("unable to activate segment: %s", err)
+	}
+	if _, _, err := client.Environments.AddSegmentKeys(ctx, environmentID, "beta", false, &api.EnvironmentSegmentKeysRequest{Keys: []string{"a", "b"}}); err != nil {
+		t.Fatalf("unable to add segment keys: %s", err)
+	}
+
+	raw["tags"] = []interface{}{"team-b"}
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.RequiresNew() {
+		t.Fatalf("expected an in-place update, got %+v", diff)
+	}
+
+	state, diags := r.Apply(ctx, d.State(), diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	d = r.Data(state)
+
+	if fmt.Sprint(getTags(d)) != "[team-b]" {
+		t.Fatalf("unexpected state: tags %v", getTags(d))
+	}
+
+	seg, _, err := client.Segments.Get(ctx, workspaceID, "beta")
+	if err != nil || fmt.Sprint(seg.TagNames()) != "[team-b]" {
+		t.Fatalf("expected the segment to be updated, got %+v: %v", seg, err)
+	}
+
+	// The title and comment are recorded with the creation of the segment.
+	for _, req := range server.Requests() {
+		if req.Method == "POST" && strings.HasPrefix(req.Path, "/segments/ws/") &&
+			!strings.Contains(req.Body, `"title":"checkout beta","comment":"targets the beta testers"`) {
+			t.Fatalf("expected %s %s to have a title and comment, got %s", req.Method, req.Path, req.Body)
+		}
+	}
+
+	// The description cannot be updated through the public API, and is not replaced either.
+	raw["description"] = "edited from Terraform"
+	if _, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config); err == nil ||
+		!strings.Contains(err.Error(), "cannot be changed") {
+		t.Fatalf("expected the description change to be rejected, got: %v", err)
+	}
+	raw["description"] = "created from Terraform"
+
+	// Updating the segment in place keeps the keys of every environment.
+	keys, _, err := client.Environments.GetSegmentKeys(ctx, environmentID, "beta")
+	if err != nil || len(keys.Keys) != 2 {
+		t.Fatalf("expected the segment keys to be kept, got %+v: %v", keys, err)
+	}
+
+	// Removing the attribute removes every tag.
+	delete(raw, "tags")
+	diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil {
+		t.Fatal("expected the tags to be removed")
+	}
+	if _, diags := r.Apply(ctx, d.State(), diff, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	seg, _, err = client.Segments.Get(ctx, workspaceID, "beta")
+	if err != nil || len(seg.TagNames()) != 0 {
+		t.Fatalf("expected every tag to be removed, got %+v: %v", seg, err)
+	}
+}
+
+func testAccCheckSplitSegment_basic(workspaceID, trafficTypeID, name, description, tag string) string {
 	return fmt.Sprintf(`
 resource "split_segment" "foobar" {
 	workspace_id = "%s"
 	traffic_type_id = "%s"
 	name = "%s"
 	description = "%s"
+	tags = ["%s"]
 }
-`, workspaceID, trafficTypeID, name, description)
+`, workspaceID, trafficTypeID, name, description, tag)
 }
@@ -18,6 +18,10 @@ func resourceSplitSplit() *schema.Resource {
 		UpdateContext: resourceSplitSplitUpdate,
 		DeleteContext: resourceSplitSplitDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
+		CustomizeDiff: resourceSplitSplitCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitSplitImport,
 		},
@@ -50,10 +54,43 @@ func resourceSplitSplit() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+
+			"tags": tagsSchema(),
+
+			"flag_sets": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
+
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 		},
 	}
 }
 
+// resourceSplitSplitCustomizeDiff only requires a title and comment for the changes that are sent with them.
+// The description and tags are updated through endpoints that accept neither, while flag set membership
+// changes are only recorded with a comment.
+func resourceSplitSplitCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return validateChangeTitleAndComment(ctx, diff, meta)
+	}
+
+	if diff.HasChange("flag_sets") {
+		return validateChangeComment(ctx, diff, meta)
+	}
+
+	return nil
+}
+
 func resourceSplitSplitImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Config).API
 
@@ -65,7 +102,7 @@ func resourceSplitSplitImport(ctx context.Context, d *schema.ResourceData, meta
 	workspaceID := importID[0]
 	splitID := importID[1]
 
-	s, _, getErr := client.Splits.Get(workspaceID, splitID)
+	s, _, getErr := client.Splits.Get(ctx, workspaceID, splitID)
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -75,13 +112,16 @@ func resourceSplitSplitImport(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("traffic_type_id", s.GetTrafficType().GetID())
 	d.Set("name", s.GetName())
 	d.Set("description", s.GetDescription())
+	d.Set("tags", s.TagNames())
+	d.Set("flag_sets", s.FlagSetIDs())
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceSplitSplitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 	opts := &api.SplitCreateRequest{}
 	workspaceID := getWorkspaceID(d)
 	trafficTypeID := getTrafficTypeID(d)
@@ -96,9 +136,11 @@ func resourceSplitSplitCreate(ctx context.Context, d *schema.ResourceData, meta
 		log.Printf("[DEBUG] new split description is : %v", opts.Description)
 	}
 
+	opts.Title, opts.Comment = changeTitleAndComment(d, config)
+
 	log.Printf("[DEBUG] Creating split %v", opts.Name)
 
-	s, _, createErr := client.Splits.Create(workspaceID, trafficTypeID, opts)
+	s, _, createErr := client.Splits.Create(ctx, workspaceID, trafficTypeID, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -113,6 +155,25 @@ func resourceSplitSplitCreate(ctx context.Context, d *schema.ResourceData, meta
 	d.SetId(s.GetID())
 	d.Set("workspace_id", workspaceID)
 
+	if tags := getTags(d); len(tags) > 0 {
+		log.Printf("[DEBUG] Tagging split %v", s.GetID())
+
+		if _, tagErr := client.Splits.UpdateTags(ctx, workspaceID, s.GetName(), tags); tagErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to tag split %v", opts.Name),
+				Detail:   tagErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Tagged split %v", s.GetID())
+	}
+
+	if flagSetDiags := updateSplitFlagSets(ctx, d, config); flagSetDiags.HasError() {
+		return flagSetDiags
+	}
+
 	return resourceSplitSplitRead(ctx, d, meta)
 }
 
@@ -127,7 +188,7 @@ func resourceSplitSplitUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 		log.Printf("[DEBUG] Updating split description %v", d.Id())
 
-		_, _, updateErr := client.Splits.UpdateDescription(workspaceID, d.Get("name").(string), description)
+		_, _, updateErr := client.Splits.UpdateDescription(ctx, workspaceID, d.Get("name").(string), description)
 		if updateErr != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -140,15 +201,90 @@ func resourceSplitSplitUpdate(ctx context.Context, d *schema.ResourceData, meta
 		log.Printf("[DEBUG] Updated split description %v", d.Id())
 	}
 
+	if ok := d.HasChange("tags"); ok {
+		tags := getTags(d)
+		log.Printf("[DEBUG] Updating split tags %v: %v", d.Id(), tags)
+
+		_, updateErr := client.Splits.UpdateTags(ctx, workspaceID, d.Get("name").(string), tags)
+		if updateErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to update split tags %v", d.Id()),
+				Detail:   updateErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Updated split tags %v", d.Id())
+	}
+
+	if ok := d.HasChange("flag_sets"); ok {
+		if flagSetDiags := updateSplitFlagSets(ctx, d, meta.(*Config)); flagSetDiags.HasError() {
+			return flagSetDiags
+		}
+	}
+
 	return resourceSplitSplitRead(ctx, d, meta)
 }
 
+// updateSplitFlagSets adds the split to the flag sets added to flag_sets and removes it from the removed ones.
+func updateSplitFlagSets(ctx context.Context, d *schema.ResourceData, config *Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := config.API
+	workspaceID := getWorkspaceID(d)
+	splitName := d.Get("name").(string)
+	_, comment := changeTitleAndComment(d, config)
+
+	o, n := d.GetChange("flag_sets")
+	oldFlagSets, newFlagSets := o.(*schema.Set), n.(*schema.Set)
+
+	if added := setToStringSlice(newFlagSets.Difference(oldFlagSets)); len(added) > 0 {
+		log.Printf("[DEBUG] Adding split %v to flag sets %v", d.Id(), added)
+
+		_, _, addErr := client.Splits.AddToFlagSets(ctx, workspaceID, splitName, &api.SplitFlagSetsRequest{IDs: added, Comment: comment})
+		if addErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to add split %v to flag sets %v", splitName, added),
+				Detail:   addErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Added split %v to flag sets %v", d.Id(), added)
+	}
+
+	if removed := setToStringSlice(oldFlagSets.Difference(newFlagSets)); len(removed) > 0 {
+		log.Printf("[DEBUG] Removing split %v from flag sets %v", d.Id(), removed)
+
+		_, _, removeErr := client.Splits.RemoveFromFlagSets(ctx, workspaceID, splitName, &api.SplitFlagSetsRequest{IDs: removed, Comment: comment})
+		if removeErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to remove split %v from flag sets %v", splitName, removed),
+				Detail:   removeErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Removed split %v from flag sets %v", d.Id(), removed)
+	}
+
+	return diags
+}
+
 func resourceSplitSplitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
 
-	s, _, getErr := client.Splits.Get(getWorkspaceID(d), d.Id())
+	s, _, getErr := client.Splits.Get(ctx, getWorkspaceID(d), d.Id())
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Split %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch split %s", d.Id()),
@@ -160,6 +296,8 @@ func resourceSplitSplitRead(ctx context.Context, d *schema.ResourceData, meta in
 	d.Set("name", s.GetName())
 	d.Set("description", s.GetDescription())
 	d.Set("traffic_type_id", s.GetTrafficType().GetID())
+	d.Set("tags", s.TagNames())
+	d.Set("flag_sets", s.FlagSetIDs())
 
 	return diags
 }
@@ -170,7 +308,7 @@ func resourceSplitSplitDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Printf("[DEBUG] Deleting split %s", d.Id())
 
-	_, deleteErr := client.Splits.Delete(getWorkspaceID(d), d.Get("name").(string))
+	_, deleteErr := client.Splits.Delete(ctx, getWorkspaceID(d), d.Get("name").(string))
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -5,17 +5,64 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
+	"time"
 
 	"github.com/davidji99/terraform-provider-split/api"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const (
+	// splitDefinitionUpdateStrategyFull replaces the entire split definition on update.
+	splitDefinitionUpdateStrategyFull = "full"
+
+	// splitDefinitionUpdateStrategyPartial only replaces the parts of the split definition that changed.
+	splitDefinitionUpdateStrategyPartial = "partial"
+
+	// defaultSplitDefinitionChangeTitle is the title of changes when neither the resource nor the provider set one.
+	defaultSplitDefinitionChangeTitle = "terraform-provider-split"
+)
+
 var (
 	errDefaultRuleSizeSum = "the sum of all default rule sizes must equal 100"
+
+	// matcherTypesWithBool are the matcher types that compare against the matcher's bool.
+	matcherTypesWithBool = []string{"EQUAL_TO_BOOLEAN"}
+
+	// matcherTypesWithNumber are the matcher types that compare against the matcher's number.
+	matcherTypesWithNumber = []string{"EQUAL_NUMBER", "GREATER_THAN_OR_EQUAL_NUMBER", "LESS_THAN_OR_EQUAL_NUMBER"}
+
+	// matcherTypesWithDate are the matcher types that compare against the matcher's date.
+	matcherTypesWithDate = []string{"ON_DATE", "ON_OR_AFTER_DATE", "ON_OR_BEFORE_DATE"}
+
+	// matcherTypesWithString are the matcher types that require the matcher's string.
+	matcherTypesWithString = []string{"IN_SEGMENT", "IN_LARGE_SEGMENT", "MATCHES_STRING"}
+
+	// matcherTypesWithStrings are the matcher types that require the matcher's strings.
+	matcherTypesWithStrings = []string{"IN_LIST_STRING", "STARTS_WITH", "ENDS_WITH", "CONTAINS_STRING",
+		"EQUAL_SET", "ANY_OF_SET", "ALL_OF_SET", "PART_OF_SET"}
+
+	// matcherTypesWithBetween are the matcher types that require the matcher's between block.
+	matcherTypesWithBetween = []string{"BETWEEN_NUMBER", "BETWEEN_DATE"}
+
+	// matcherTypesWithDepends are the matcher types that require the matcher's depends block.
+	matcherTypesWithDepends = []string{"IN_SPLIT"}
 )
 
+// splitDefinitionDiff is the subset of *schema.ResourceDiff used to validate a split definition.
+type splitDefinitionDiff interface {
+	Get(key string) interface{}
+	NewValueKnown(key string) bool
+}
+
+// splitDefinitionChange is the subset of *schema.ResourceData used to determine what changed in a split definition.
+type splitDefinitionChange interface {
+	HasChange(key string) bool
+}
+
 func resourceSplitSplitDefinition() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSplitSplitDefinitionCreate,
@@ -23,6 +70,10 @@ func resourceSplitSplitDefinition() *schema.Resource {
 		UpdateContext: resourceSplitSplitDefinitionUpdate,
 		DeleteContext: resourceSplitSplitDefinitionDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
+		CustomizeDiff: resourceSplitSplitDefinitionCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitSplitDefinitionImport,
 		},
@@ -49,20 +100,75 @@ func resourceSplitSplitDefinition() *schema.Resource {
 			},
 
 			"default_treatment": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"default_treatment", "definition_json"},
 			},
 
 			"traffic_allocation": {
-				Type:     schema.TypeInt,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"definition_json"},
+			},
+
+			"configurations_schema": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateConfigurationsSchema,
+			},
+
+			"definition_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateSplitDefinitionJSON,
+				DiffSuppressFunc: suppressEquivalentSplitDefinitionJSON,
+			},
+
+			"killed": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressKilledDiff,
+			},
+
+			"ignore_kill_state": {
+				Type:     schema.TypeBool,
 				Optional: true,
-				Computed: true,
+				Default:  false,
 			},
 
+			"manage_individual_targets": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"update_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  splitDefinitionUpdateStrategyFull,
+				ValidateFunc: validation.StringInSlice([]string{splitDefinitionUpdateStrategyFull,
+					splitDefinitionUpdateStrategyPartial}, false),
+			},
+
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"change_request": changeRequestSchema(false),
+
 			"treatment": {
-				Type:     schema.TypeList,
-				Required: true,
-				MinItems: 1,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MinItems:     1,
+				ExactlyOneOf: []string{"treatment", "definition_json"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -71,9 +177,20 @@ func resourceSplitSplitDefinition() *schema.Resource {
 						},
 
 						"configurations": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringIsJSON,
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							StateFunc:        normalizeConfigurations,
+							DiffSuppressFunc: suppressEquivalentConfigurationsDiff,
+						},
+
+						"configurations_object": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							DiffSuppressFunc: suppressEquivalentConfigurationsDiff,
 						},
 
 						"description": {
@@ -87,7 +204,8 @@ func resourceSplitSplitDefinition() *schema.Resource {
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
-							Optional: true,
+							Optional:         true,
+							DiffSuppressFunc: suppressIndividualTargetsDiff,
 						},
 
 						"segments": {
@@ -95,16 +213,18 @@ func resourceSplitSplitDefinition() *schema.Resource {
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
-							Optional: true,
+							Optional:         true,
+							DiffSuppressFunc: suppressIndividualTargetsDiff,
 						},
 					},
 				},
 			},
 
 			"default_rule": {
-				Type:     schema.TypeList,
-				Required: true,
-				MinItems: 1,
+				Type:         schema.TypeList,
+				Optional:     true,
+				MinItems:     1,
+				ExactlyOneOf: []string{"default_rule", "definition_json"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"treatment": {
@@ -121,8 +241,9 @@ func resourceSplitSplitDefinition() *schema.Resource {
 			},
 
 			"rule": {
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"definition_json"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"bucket": {
@@ -176,6 +297,68 @@ func resourceSplitSplitDefinition() *schema.Resource {
 													},
 													Optional: true,
 												},
+
+												"negate": {
+													Type:     schema.TypeBool,
+													Optional: true,
+													Default:  false,
+												},
+
+												"bool": {
+													Type:     schema.TypeBool,
+													Optional: true,
+												},
+
+												"number": {
+													Type:     schema.TypeInt,
+													Optional: true,
+												},
+
+												"date": {
+													Type:     schema.TypeInt,
+													Optional: true,
+												},
+
+												"between": {
+													Type:     schema.TypeList,
+													Optional: true,
+													MaxItems: 1,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"from": {
+																Type:     schema.TypeInt,
+																Required: true,
+															},
+
+															"to": {
+																Type:     schema.TypeInt,
+																Required: true,
+															},
+														},
+													},
+												},
+
+												"depends": {
+													Type:     schema.TypeList,
+													Optional: true,
+													MaxItems: 1,
+													Elem: &schema.Resource{
+														Schema: map[string]*schema.Schema{
+															"split_name": {
+																Type:     schema.TypeString,
+																Required: true,
+															},
+
+															"treatments": {
+																Type: schema.TypeSet,
+																Elem: &schema.Schema{
+																	Type: schema.TypeString,
+																},
+																Required: true,
+															},
+														},
+													},
+												},
 											},
 										},
 									},
@@ -194,6 +377,189 @@ func resourceSplitSplitDefinition() *schema.Resource {
 	}
 }
 
+// resourceSplitSplitDefinitionCustomizeDiff validates the split definition at plan time, so that invalid
+// definitions are reported before any API request is made.
+func resourceSplitSplitDefinitionCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	var definition splitDefinitionDiff
+	switch {
+	case !diff.NewValueKnown("definition_json"):
+		// The document is validated once it is known.
+	case diff.Get("definition_json").(string) != "":
+		opts, err := expandSplitDefinitionJSON(diff.Get("definition_json").(string))
+		if err != nil {
+			return fmt.Errorf("definition_json: %w", err)
+		}
+		definition = newSplitDefinitionDocumentDiff(opts)
+		if err := validateSplitDefinition(definition); err != nil {
+			return fmt.Errorf("definition_json: %w", err)
+		}
+	default:
+		definition = diff
+		if err := validateSplitDefinition(definition); err != nil {
+			return err
+		}
+	}
+
+	if v := diff.Get("configurations_schema").(string); definition != nil && v != "" && diff.NewValueKnown("configurations_schema") {
+		if err := validateTreatmentConfigurations(definition, v); err != nil {
+			return err
+		}
+	}
+
+	return validateSplitDefinitionChangeNotes(ctx, diff, meta)
+}
+
+// validateSplitDefinition returns every problem found in the split definition. Values that are not
+// known until apply are not validated.
+func validateSplitDefinition(d splitDefinitionDiff) error {
+	var errs []error
+
+	if d.NewValueKnown("traffic_allocation") {
+		if v := d.Get("traffic_allocation").(int); v < 0 || v > 100 {
+			errs = append(errs, fmt.Errorf("traffic_allocation must be between 0 and 100, got %d", v))
+		}
+	}
+
+	// Treatment names are only validated when all of them are known.
+	treatments := make(map[string]bool)
+	treatmentsKnown := d.NewValueKnown("treatment")
+	for i := range d.Get("treatment").([]interface{}) {
+		key := fmt.Sprintf("treatment.%d.name", i)
+		if !d.NewValueKnown(key) {
+			treatmentsKnown = false
+			continue
+		}
+
+		if allValuesKnown(d, fmt.Sprintf("treatment.%d", i), "configurations", "configurations_object") {
+			configurations, _ := d.Get(fmt.Sprintf("treatment.%d.configurations", i)).(string)
+			configurationsObject, _ := d.Get(fmt.Sprintf("treatment.%d.configurations_object", i)).(map[string]interface{})
+			if configurations != "" && len(configurationsObject) > 0 {
+				errs = append(errs, fmt.Errorf("treatment.%d: only one of configurations or configurations_object can be set", i))
+			}
+		}
+
+		name := d.Get(key).(string)
+		if treatments[name] {
+			errs = append(errs, fmt.Errorf("treatment names must be unique, %q is declared more than once", name))
+		}
+		treatments[name] = true
+	}
+
+	checkTreatment := func(key string) {
+		if !treatmentsKnown || !d.NewValueKnown(key) {
+			return
+		}
+		if name := d.Get(key).(string); !treatments[name] {
+			errs = append(errs, fmt.Errorf("%s %q is not one of the declared treatments", key, name))
+		}
+	}
+
+	checkTreatment("default_treatment")
+
+	defaultRuleSizeSum, defaultRuleSizesKnown := 0, true
+	for i := range d.Get("default_rule").([]interface{}) {
+		checkTreatment(fmt.Sprintf("default_rule.%d.treatment", i))
+
+		sizeKey := fmt.Sprintf("default_rule.%d.size", i)
+		if !d.NewValueKnown(sizeKey) {
+			defaultRuleSizesKnown = false
+			continue
+		}
+		defaultRuleSizeSum += d.Get(sizeKey).(int)
+	}
+	if defaultRuleSizesKnown && defaultRuleSizeSum != 100 {
+		errs = append(errs, errors.New(errDefaultRuleSizeSum))
+	}
+
+	for i, ruleRaw := range d.Get("rule").([]interface{}) {
+		rule, ok := ruleRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sizeSum, sizesKnown := 0, true
+		buckets, _ := rule["bucket"].([]interface{})
+		for j := range buckets {
+			checkTreatment(fmt.Sprintf("rule.%d.bucket.%d.treatment", i, j))
+
+			sizeKey := fmt.Sprintf("rule.%d.bucket.%d.size", i, j)
+			if !d.NewValueKnown(sizeKey) {
+				sizesKnown = false
+				continue
+			}
+			sizeSum += d.Get(sizeKey).(int)
+		}
+		if sizesKnown && sizeSum != 100 {
+			errs = append(errs, fmt.Errorf("the sum of all bucket sizes of rule.%d must equal 100, got %d", i, sizeSum))
+		}
+
+		conditions, _ := rule["condition"].([]interface{})
+		for j, conditionRaw := range conditions {
+			condition, ok := conditionRaw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			matchers, _ := condition["matcher"].([]interface{})
+			for k, matcherRaw := range matchers {
+				matcher, ok := matcherRaw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				key := fmt.Sprintf("rule.%d.condition.%d.matcher.%d", i, j, k)
+				if !allValuesKnown(d, key, "type", "string", "strings", "between", "depends") {
+					continue
+				}
+				if err := validateMatcher(key, matcher); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// allValuesKnown returns true if the values of all fields under prefix are known.
+func allValuesKnown(d splitDefinitionDiff, prefix string, fields ...string) bool {
+	for _, f := range fields {
+		if !d.NewValueKnown(prefix + "." + f) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateMatcher checks that a matcher sets the fields its type requires.
+//
+// The bool, number and date fields cannot be checked as their zero values are valid.
+// Unknown matcher types are not validated.
+func validateMatcher(key string, matcher map[string]interface{}) error {
+	matcherType, _ := matcher["type"].(string)
+
+	switch {
+	case slices.Contains(matcherTypesWithString, matcherType):
+		if v, _ := matcher["string"].(string); v == "" {
+			return fmt.Errorf("%s: matcher type %s requires string to be set", key, matcherType)
+		}
+	case slices.Contains(matcherTypesWithStrings, matcherType):
+		if v, _ := matcher["strings"].([]interface{}); len(v) == 0 {
+			return fmt.Errorf("%s: matcher type %s requires strings to be set", key, matcherType)
+		}
+	case slices.Contains(matcherTypesWithBetween, matcherType):
+		if v, _ := matcher["between"].([]interface{}); len(v) == 0 {
+			return fmt.Errorf("%s: matcher type %s requires a between block", key, matcherType)
+		}
+	case slices.Contains(matcherTypesWithDepends, matcherType):
+		if v, _ := matcher["depends"].([]interface{}); len(v) == 0 {
+			return fmt.Errorf("%s: matcher type %s requires a depends block", key, matcherType)
+		}
+	}
+
+	return nil
+}
+
 func resourceSplitSplitDefinitionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Config).API
 
@@ -206,7 +572,7 @@ func resourceSplitSplitDefinitionImport(ctx context.Context, d *schema.ResourceD
 	splitName := importID[1]
 	environmentID := importID[2]
 
-	sd, _, getErr := client.Splits.GetDefinition(workspaceID, splitName, environmentID)
+	sd, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, environmentID)
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -217,6 +583,8 @@ func resourceSplitSplitDefinitionImport(ctx context.Context, d *schema.ResourceD
 	d.Set("environment_id", sd.GetEnvironment().GetID())
 	d.Set("traffic_allocation", sd.GetTrafficAllocation())
 	d.Set("default_treatment", sd.GetDefaultTreatment())
+	d.Set("killed", sd.GetKilled())
+	d.Set("manage_individual_targets", true)
 
 	// Set Treatment in state
 	setTreatmentInState(d, sd)
@@ -230,9 +598,39 @@ func resourceSplitSplitDefinitionImport(ctx context.Context, d *schema.ResourceD
 	return []*schema.ResourceData{d}, nil
 }
 
+// waitForSplitDefinition waits until the definition of a split exists in the environment and returns it.
+func waitForSplitDefinition(ctx context.Context, client *api.Client, workspaceID, splitName, environmentID string,
+	pollInterval, timeout time.Duration) (*api.SplitDefinition, error) {
+	conf := &retry.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"created"},
+		Refresh: func() (interface{}, string, error) {
+			sd, _, err := client.Splits.GetDefinition(ctx, workspaceID, splitName, environmentID)
+			if err != nil {
+				if api.IsNotFound(err) {
+					log.Printf("[DEBUG] Waiting for the definition of split %s to be published", splitName)
+					return false, "pending", nil
+				}
+				return nil, "", err
+			}
+			return sd, "created", nil
+		},
+		Timeout:      timeout,
+		PollInterval: pollInterval,
+	}
+
+	result, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*api.SplitDefinition), nil
+}
+
 func resourceSplitSplitDefinitionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 
 	workspaceID := getWorkspaceID(d)
 	splitName := getSplitName(d)
@@ -247,58 +645,192 @@ func resourceSplitSplitDefinitionCreate(ctx context.Context, d *schema.ResourceD
 		})
 		return diags
 	}
+	opts.Title, opts.Comment = splitDefinitionChangeTitleAndComment(d, config)
 
-	log.Printf("[DEBUG] Creating definition on split [%v]", splitName)
+	var sd *api.SplitDefinition
+	if cr := getChangeRequestOptions(d); cr != nil {
+		req := constructSplitDefinitionChangeRequest(splitName, api.ChangeRequestOperationCreate, opts)
+		if crDiags := submitChangeRequest(ctx, client, environmentID, cr, req, d.Timeout(schema.TimeoutCreate)); crDiags.HasError() {
+			return crDiags
+		}
 
-	sd, _, createErr := client.Splits.CreateDefinition(workspaceID, splitName, environmentID, opts)
-	if createErr != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Unable to create definition for split %v", splitName),
-			Detail:   createErr.Error(),
-		})
-		return diags
-	}
+		// Change requests do not return the definition they create, which only exists once the approved change
+		// request is published, for example at its scheduled time.
+		var getErr error
+		sd, getErr = waitForSplitDefinition(ctx, client, workspaceID, splitName, environmentID, cr.pollInterval,
+			d.Timeout(schema.TimeoutCreate))
+		if getErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to fetch the created definition of split %v", splitName),
+				Detail:   getErr.Error(),
+			})
+			return diags
+		}
+	} else {
+		log.Printf("[DEBUG] Creating definition on split [%v]", splitName)
+
+		var createErr error
+		sd, _, createErr = client.Splits.CreateDefinition(ctx, workspaceID, splitName, environmentID, opts)
+		if createErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to create definition for split %v", splitName),
+				Detail:   createErr.Error(),
+			})
+			return diags
+		}
 
-	log.Printf("[DEBUG] Created definition on split [%v]", splitName)
+		log.Printf("[DEBUG] Created definition on split [%v]", splitName)
+	}
 
 	d.SetId(sd.GetID())
 
+	if d.Get("killed").(bool) && !d.Get("ignore_kill_state").(bool) {
+		if killDiags := setSplitDefinitionKilled(ctx, d, config, true, d.Timeout(schema.TimeoutCreate)); killDiags.HasError() {
+			return killDiags
+		}
+	}
+
 	return resourceSplitSplitDefinitionRead(ctx, d, meta)
 }
 
 func resourceSplitSplitDefinitionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 
 	workspaceID := getWorkspaceID(d)
 	environmentID := getEnvironmentID(d)
 	splitName := getSplitName(d)
-	opts, optsErr := constructSplitDefinitionRequestOpts(d)
-	if optsErr != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Unable to construct definition for split %v", splitName),
-			Detail:   optsErr.Error(),
-		})
-		return diags
+
+	// Changes to attributes that only affect how the resource behaves do not require updating the definition.
+	if d.HasChangesExcept("killed", "ignore_kill_state", "manage_individual_targets", "update_strategy",
+		"configurations_schema", "title", "comment", "change_request") {
+		opts, optsErr := constructSplitDefinitionRequestOpts(d)
+		if optsErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to construct definition for split %v", splitName),
+				Detail:   optsErr.Error(),
+			})
+			return diags
+		}
+		opts.Title, opts.Comment = splitDefinitionChangeTitleAndComment(d, config)
+
+		// A full update replaces the individual targets, so keep the ones managed outside of Terraform.
+		if !manageIndividualTargets(d) {
+			current, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, environmentID)
+			if getErr != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Unable to fetch the individual targets of split definition %v", d.Id()),
+					Detail:   getErr.Error(),
+				})
+				return diags
+			}
+			preserveIndividualTargets(opts, current)
+		}
+
+		var updateErr error
+		if cr := getChangeRequestOptions(d); cr != nil {
+			// Change requests always propose the entire definition.
+			req := constructSplitDefinitionChangeRequest(splitName, api.ChangeRequestOperationUpdate, opts)
+			if crDiags := submitChangeRequest(ctx, client, environmentID, cr, req, d.Timeout(schema.TimeoutUpdate)); crDiags.HasError() {
+				return crDiags
+			}
+		} else if d.Get("update_strategy").(string) == splitDefinitionUpdateStrategyPartial {
+			log.Printf("[DEBUG] Partially updating split definition %v", d.Id())
+			_, _, updateErr = client.Splits.UpdateDefinitionPartial(ctx, workspaceID, splitName, environmentID,
+				constructSplitDefinitionPatch(d, opts),
+				api.SplitDefinitionPatchQueryParams{Title: opts.Title, Comment: opts.Comment})
+		} else {
+			log.Printf("[DEBUG] Updating split definition %v", d.Id())
+			_, _, updateErr = client.Splits.UpdateDefinitionFull(ctx, workspaceID, splitName, environmentID, opts)
+		}
+		if updateErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to update split definition %v", d.Id()),
+				Detail:   updateErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Updated split definition %v", d.Id())
 	}
 
-	log.Printf("[DEBUG] Updating split definition %v", d.Id())
+	if d.HasChange("killed") && !d.Get("ignore_kill_state").(bool) {
+		killed := d.Get("killed").(bool)
+		if killDiags := setSplitDefinitionKilled(ctx, d, config, killed, d.Timeout(schema.TimeoutUpdate)); killDiags.HasError() {
+			return killDiags
+		}
+	}
 
-	_, _, updateErr := client.Splits.UpdateDefinitionFull(workspaceID, splitName, environmentID, opts)
-	if updateErr != nil {
+	return resourceSplitSplitDefinitionRead(ctx, d, meta)
+}
+
+// setSplitDefinitionKilled kills or restores the split definition, directly or through a change request.
+func setSplitDefinitionKilled(ctx context.Context, d *schema.ResourceData, config *Config, killed bool, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := config.API
+	workspaceID := getWorkspaceID(d)
+	environmentID := getEnvironmentID(d)
+	splitName := getSplitName(d)
+	opts := &api.SplitKillRequest{}
+	opts.Title, opts.Comment = splitDefinitionChangeTitleAndComment(d, config)
+
+	action, operation := "restore", api.ChangeRequestOperationRestore
+	if killed {
+		action, operation = "kill", api.ChangeRequestOperationKill
+	}
+
+	if cr := getChangeRequestOptions(d); cr != nil {
+		req := &api.ChangeRequestRequest{
+			Split:         &api.ChangeRequestSplit{Name: splitName},
+			OperationType: operation,
+			Title:         opts.Title,
+			Comment:       opts.Comment,
+		}
+		return submitChangeRequest(ctx, client, environmentID, cr, req, timeout)
+	}
+
+	log.Printf("[DEBUG] Setting split definition %v to %s", d.Id(), action)
+
+	var err error
+	if killed {
+		_, _, err = client.Splits.Kill(ctx, workspaceID, splitName, environmentID, opts)
+	} else {
+		_, _, err = client.Splits.Restore(ctx, workspaceID, splitName, environmentID, opts)
+	}
+	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Unable to update split definition %v", d.Id()),
-			Detail:   updateErr.Error(),
+			Summary:  fmt.Sprintf("Unable to %s split definition %v", action, d.Id()),
+			Detail:   err.Error(),
 		})
-		return diags
 	}
 
-	log.Printf("[DEBUG] Updating split definition %v", d.Id())
+	return diags
+}
 
-	return resourceSplitSplitDefinitionRead(ctx, d, meta)
+// constructSplitDefinitionChangeRequest returns the change request proposing the split definition.
+func constructSplitDefinitionChangeRequest(splitName, operation string, opts *api.SplitDefinitionRequest) *api.ChangeRequestRequest {
+	trafficAllocation := opts.TrafficAllocation
+
+	return &api.ChangeRequestRequest{
+		Split: &api.ChangeRequestSplit{
+			Name:              splitName,
+			Treatments:        opts.Treatments,
+			Rules:             opts.Rules,
+			DefaultRule:       opts.DefaultRule,
+			DefaultTreatment:  opts.DefaultTreatment,
+			TrafficAllocation: &trafficAllocation,
+		},
+		OperationType: operation,
+		Title:         opts.Title,
+		Comment:       opts.Comment,
+	}
 }
 
 func resourceSplitSplitDefinitionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -309,8 +841,14 @@ func resourceSplitSplitDefinitionRead(ctx context.Context, d *schema.ResourceDat
 	environmentID := getEnvironmentID(d)
 	splitName := getSplitName(d)
 
-	sd, _, getErr := client.Splits.GetDefinition(workspaceID, splitName, environmentID)
+	sd, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, environmentID)
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Split definition %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch split %s", d.Id()),
@@ -323,6 +861,34 @@ func resourceSplitSplitDefinitionRead(ctx context.Context, d *schema.ResourceDat
 	d.Set("split_name", sd.GetName())
 	d.Set("environment_id", sd.GetEnvironment().GetID())
 	d.Set("traffic_allocation", sd.GetTrafficAllocation())
+	d.Set("killed", sd.GetKilled())
+
+	if v, ok := d.GetOk("definition_json"); ok {
+		manageTargets := manageIndividualTargets(d)
+		definitionJSON, flattenErr := flattenSplitDefinitionJSON(splitDefinitionRequestFromDefinition(sd), manageTargets)
+		if flattenErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("unable to encode split definition %s", d.Id()),
+				Detail:   flattenErr.Error(),
+			})
+			return diags
+		}
+
+		// Keep the configured document when it is equivalent, so that its formatting is preserved.
+		if !splitDefinitionJSONEquivalent(v.(string), definitionJSON, manageTargets) {
+			d.Set("definition_json", definitionJSON)
+		}
+
+		// The definition is managed by the document, so the nested blocks are left empty.
+		d.Set("default_treatment", "")
+		d.Set("treatment", nil)
+		d.Set("default_rule", nil)
+		d.Set("rule", nil)
+
+		return diags
+	}
+
 	d.Set("default_treatment", sd.GetDefaultTreatment())
 
 	// Set Treatment in state
@@ -338,16 +904,34 @@ func resourceSplitSplitDefinitionRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceSplitSplitDefinitionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*Config).API
+	config := meta.(*Config)
+	client := config.API
 	var diags diag.Diagnostics
 
 	workspaceID := getWorkspaceID(d)
 	envID := getEnvironmentID(d)
 	splitName := getSplitName(d)
 
+	if cr := getChangeRequestOptions(d); cr != nil {
+		title, comment := splitDefinitionChangeTitleAndComment(d, config)
+		req := &api.ChangeRequestRequest{
+			Split:         &api.ChangeRequestSplit{Name: splitName},
+			OperationType: api.ChangeRequestOperationArchive,
+			Title:         title,
+			Comment:       comment,
+		}
+		if crDiags := submitChangeRequest(ctx, client, envID, cr, req, d.Timeout(schema.TimeoutDelete)); crDiags.HasError() {
+			return crDiags
+		}
+
+		d.SetId("")
+
+		return diags
+	}
+
 	log.Printf("[DEBUG] Deleting split definition %s", d.Id())
 
-	_, deleteErr := client.Splits.RemoveDefinition(workspaceID, splitName, envID)
+	_, deleteErr := client.Splits.RemoveDefinition(ctx, workspaceID, splitName, envID)
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -365,9 +949,22 @@ func resourceSplitSplitDefinitionDelete(ctx context.Context, d *schema.ResourceD
 }
 
 func constructSplitDefinitionRequestOpts(d *schema.ResourceData) (*api.SplitDefinitionRequest, error) {
-	opts := &api.SplitDefinitionRequest{}
+	if v, ok := d.GetOk("definition_json"); ok {
+		opts, err := expandSplitDefinitionJSON(v.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		if !manageIndividualTargets(d) {
+			for i := range opts.Treatments {
+				opts.Treatments[i].Keys, opts.Treatments[i].Segments = nil, nil
+			}
+		}
 
-	opts.Title = "terraform-provider-split"
+		return opts, nil
+	}
+
+	opts := &api.SplitDefinitionRequest{}
 
 	if v, ok := d.GetOk("default_treatment"); ok {
 		opts.DefaultTreatment = v.(string)
@@ -382,6 +979,7 @@ func constructSplitDefinitionRequestOpts(d *schema.ResourceData) (*api.SplitDefi
 	if v, ok := d.GetOk("treatment"); ok {
 		treatments := make([]api.Treatment, 0)
 		vL := v.([]interface{})
+		manageTargets := manageIndividualTargets(d)
 
 		for _, v := range vL {
 			vt := v.(map[string]interface{})
@@ -391,15 +989,17 @@ func constructSplitDefinitionRequestOpts(d *schema.ResourceData) (*api.SplitDefi
 				t.Name = &v
 			}
 
-			if v, ok := vt["configurations"].(string); ok {
-				t.Configurations = &v
+			configurations, configurationsErr := treatmentConfigurations(vt)
+			if configurationsErr != nil {
+				return nil, configurationsErr
 			}
+			t.Configurations = &configurations
 
 			if v, ok := vt["description"].(string); ok {
 				t.Description = &v
 			}
 
-			if v, ok := vt["keys"]; ok {
+			if v, ok := vt["keys"]; ok && manageTargets {
 				vL := v.(*schema.Set).List()
 				keys := make([]string, 0)
 				for _, e := range vL {
@@ -408,7 +1008,7 @@ func constructSplitDefinitionRequestOpts(d *schema.ResourceData) (*api.SplitDefi
 				t.Keys = keys
 			}
 
-			if v, ok := vt["segments"]; ok {
+			if v, ok := vt["segments"]; ok && manageTargets {
 				vL := v.(*schema.Set).List()
 				segments := make([]string, 0)
 				for _, e := range vL {
@@ -493,27 +1093,8 @@ func constructSplitDefinitionRequestOpts(d *schema.ResourceData) (*api.SplitDefi
 						matchersList := matchersListRaw.([]interface{})
 						newRuleConditionMatchers := make([]*api.Matcher, 0)
 						for _, matcherRaw := range matchersList {
-							matcher := matcherRaw.(map[string]interface{})
-							newRuleConditionMatcher := api.Matcher{}
-
-							if v, ok := matcher["type"].(string); ok {
-								newRuleConditionMatcher.Type = &v
-							}
-							if v, ok := matcher["attribute"].(string); ok {
-								newRuleConditionMatcher.Attribute = &v
-							}
-							if v, ok := matcher["string"].(string); ok {
-								newRuleConditionMatcher.String = &v
-							}
-							if v, ok := matcher["strings"]; ok {
-								stringsRaw := v.([]interface{})
-								sList := make([]string, 0)
-								for _, s := range stringsRaw {
-									sList = append(sList, s.(string))
-								}
-								newRuleConditionMatcher.Strings = sList
-							}
-							newRuleConditionMatchers = append(newRuleConditionMatchers, &newRuleConditionMatcher)
+							newRuleConditionMatchers = append(newRuleConditionMatchers,
+								constructMatcher(matcherRaw.(map[string]interface{})))
 						}
 						newRuleCondition.Matchers = newRuleConditionMatchers
 					}
@@ -529,31 +1110,122 @@ func constructSplitDefinitionRequestOpts(d *schema.ResourceData) (*api.SplitDefi
 }
 
 func setTreatmentInState(d *schema.ResourceData, sd *api.SplitDefinition) {
+	manageTargets := manageIndividualTargets(d)
+
+	// Treatments configured with configurations_object keep reading their configurations into it.
+	usesConfigurationsObject := make(map[string]bool)
+	for _, raw := range d.Get("treatment").([]interface{}) {
+		if t, ok := raw.(map[string]interface{}); ok {
+			m, _ := t["configurations_object"].(map[string]interface{})
+			usesConfigurationsObject[t["name"].(string)] = len(m) > 0
+		}
+	}
+
 	treatments := make([]map[string]interface{}, 0)
 	for _, t := range sd.Treatments {
 		treatment := map[string]interface{}{
 			"name":           t.GetName(),
-			"configurations": t.GetConfigurations(),
+			"configurations": normalizeConfigurations(t.GetConfigurations()),
 			"description":    t.GetDescription(),
 		}
 
-		if t.HasKeys() {
+		if usesConfigurationsObject[t.GetName()] {
+			if m, ok := flattenConfigurationsObject(t.GetConfigurations()); ok {
+				treatment["configurations"] = ""
+				treatment["configurations_object"] = m
+			}
+		}
+
+		if t.HasKeys() && manageTargets {
 			treatment["keys"] = t.Keys
 		}
 
-		if t.HasSegments() {
+		if t.HasSegments() && manageTargets {
 			treatment["segments"] = t.Segments
 		}
 
-		treatments = append(treatments, map[string]interface{}{
-			"name":           t.GetName(),
-			"configurations": t.GetConfigurations(),
-			"description":    t.GetDescription(),
-		})
+		treatments = append(treatments, treatment)
 	}
 	d.Set("treatment", treatments)
 }
 
+// validateSplitDefinitionChangeNotes fails the plan of a change to a split definition when its workspace requires a
+// title and comment for every change and no comment is configured. The title is not checked, as
+// splitDefinitionChangeTitleAndComment always falls back to defaultSplitDefinitionChangeTitle.
+func validateSplitDefinitionChangeNotes(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	return validateChangeComment(ctx, diff, meta)
+}
+
+// splitDefinitionChangeTitleAndComment returns the title and comment recorded with changes to the split definition.
+func splitDefinitionChangeTitleAndComment(d *schema.ResourceData, config *Config) (string, string) {
+	title, comment := changeTitleAndComment(d, config)
+	if title == "" {
+		title = defaultSplitDefinitionChangeTitle
+	}
+
+	return title, comment
+}
+
+// constructSplitDefinitionPatch returns the operations replacing the parts of the split definition that changed.
+func constructSplitDefinitionPatch(d splitDefinitionChange, opts *api.SplitDefinitionRequest) []api.SplitDefinitionPatch {
+	patch := make([]api.SplitDefinitionPatch, 0)
+
+	// Every part of the definition is replaced when the document changes, as it is not compared part by part.
+	replace := func(key, path string, value interface{}) {
+		if d.HasChange(key) || d.HasChange("definition_json") {
+			patch = append(patch, api.SplitDefinitionPatch{Op: "replace", Path: path, Value: value})
+		}
+	}
+
+	// Removing every rule must send an empty list rather than null.
+	rules := opts.Rules
+	if rules == nil {
+		rules = make([]api.Rule, 0)
+	}
+
+	replace("treatment", "/treatments", opts.Treatments)
+	replace("default_treatment", "/defaultTreatment", opts.DefaultTreatment)
+	replace("default_rule", "/defaultRule", opts.DefaultRule)
+	replace("rule", "/rules", rules)
+	replace("traffic_allocation", "/trafficAllocation", opts.TrafficAllocation)
+
+	return patch
+}
+
+// manageIndividualTargets returns whether the individual targets of each treatment are managed by Terraform.
+// It defaults to true for resources created before manage_individual_targets was introduced.
+func manageIndividualTargets(d *schema.ResourceData) bool {
+	// GetOkExists is used to tell an explicit false apart from an unset value.
+	if v, ok := d.GetOkExists("manage_individual_targets"); ok {
+		return v.(bool)
+	}
+	return true
+}
+
+// suppressKilledDiff ignores changes to the kill state when ignore_kill_state is set, so that kills performed
+// outside of Terraform, for example during an incident, are not reverted.
+func suppressKilledDiff(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Get("ignore_kill_state").(bool)
+}
+
+// suppressIndividualTargetsDiff ignores changes to treatment keys and segments when they are not managed by Terraform.
+func suppressIndividualTargetsDiff(_, _, _ string, d *schema.ResourceData) bool {
+	return !manageIndividualTargets(d)
+}
+
+// preserveIndividualTargets copies the keys and segments of each treatment in the current definition to the
+// treatment of the same name in the request.
+func preserveIndividualTargets(opts *api.SplitDefinitionRequest, current *api.SplitDefinition) {
+	for i := range opts.Treatments {
+		for _, t := range current.Treatments {
+			if t.GetName() == opts.Treatments[i].GetName() {
+				opts.Treatments[i].Keys = t.Keys
+				opts.Treatments[i].Segments = t.Segments
+			}
+		}
+	}
+}
+
 func setDefaultRuleInState(d *schema.ResourceData, sd *api.SplitDefinition) {
 	defaultRule := make([]map[string]interface{}, 0)
 	for _, dr := range sd.DefaultRule {
@@ -578,16 +1250,11 @@ func setRuleInState(d *schema.ResourceData, sd *api.SplitDefinition) {
 
 		conditions := make([]map[string]interface{}, 0)
 		ruleConditionMatchers := make([]map[string]interface{}, 0)
-		for _, rcm := range r.Condition.Matchers {
-			ruleConditionMatchers = append(ruleConditionMatchers, map[string]interface{}{
-				"type":      rcm.GetType(),
-				"attribute": rcm.GetAttribute(),
-				"string":    rcm.GetString(),
-				"strings":   rcm.Strings,
-			})
+		for _, rcm := range r.GetCondition().Matchers {
+			ruleConditionMatchers = append(ruleConditionMatchers, flattenMatcher(rcm))
 		}
 		conditions = append(conditions, map[string]interface{}{
-			"combiner": *r.Condition.Combiner,
+			"combiner": r.GetCondition().GetCombiner(),
 			"matcher":  ruleConditionMatchers,
 		})
 
@@ -598,3 +1265,94 @@ func setRuleInState(d *schema.ResourceData, sd *api.SplitDefinition) {
 	}
 	d.Set("rule", rules)
 }
+
+// constructMatcher converts a rule condition matcher from the schema to its API representation.
+//
+// The bool, number and date values are sent whenever the matcher type compares against them, so that
+// zero values such as false or 0 are not dropped.
+func constructMatcher(matcher map[string]interface{}) *api.Matcher {
+	m := &api.Matcher{}
+
+	if v, ok := matcher["type"].(string); ok {
+		m.Type = &v
+	}
+	if v, ok := matcher["attribute"].(string); ok {
+		m.Attribute = &v
+	}
+	if v, ok := matcher["string"].(string); ok {
+		m.String = &v
+	}
+	if v, ok := matcher["strings"]; ok {
+		stringsRaw := v.([]interface{})
+		sList := make([]string, 0)
+		for _, s := range stringsRaw {
+			sList = append(sList, s.(string))
+		}
+		m.Strings = sList
+	}
+	if v, ok := matcher["negate"].(bool); ok {
+		m.Negate = &v
+	}
+	if v, ok := matcher["bool"].(bool); ok && (v || slices.Contains(matcherTypesWithBool, m.GetType())) {
+		m.Bool = &v
+	}
+	if v, ok := matcher["number"].(int); ok && (v != 0 || slices.Contains(matcherTypesWithNumber, m.GetType())) {
+		m.Number = &v
+	}
+	if v, ok := matcher["date"].(int); ok && (v != 0 || slices.Contains(matcherTypesWithDate, m.GetType())) {
+		m.Date = &v
+	}
+	if v, ok := matcher["between"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		between := v[0].(map[string]interface{})
+		from := between["from"].(int)
+		to := between["to"].(int)
+		m.Between = &api.Between{From: &from, To: &to}
+	}
+	if v, ok := matcher["depends"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		depends := v[0].(map[string]interface{})
+		splitName := depends["split_name"].(string)
+		treatments := make([]string, 0)
+		for _, t := range depends["treatments"].(*schema.Set).List() {
+			treatments = append(treatments, t.(string))
+		}
+		m.Depends = &api.Depends{SplitName: &splitName, Treatments: treatments}
+	}
+
+	return m
+}
+
+// flattenMatcher converts a rule condition matcher returned by the API to its schema representation.
+func flattenMatcher(m *api.Matcher) map[string]interface{} {
+	matcher := map[string]interface{}{
+		"type":      m.GetType(),
+		"attribute": m.GetAttribute(),
+		"string":    m.GetString(),
+		"strings":   m.Strings,
+		"negate":    m.GetNegate(),
+		"bool":      m.GetBool(),
+		"number":    m.GetNumber(),
+		"date":      m.GetDate(),
+		"between":   []interface{}{},
+		"depends":   []interface{}{},
+	}
+
+	if m.Between != nil {
+		matcher["between"] = []interface{}{
+			map[string]interface{}{
+				"from": m.GetBetween().GetFrom(),
+				"to":   m.GetBetween().GetTo(),
+			},
+		}
+	}
+
+	if m.Depends != nil {
+		matcher["depends"] = []interface{}{
+			map[string]interface{}{
+				"split_name": m.GetDepends().GetSplitName(),
+				"treatments": m.GetDepends().Treatments,
+			},
+		}
+	}
+
+	return matcher
+}
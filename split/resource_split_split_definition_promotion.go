@@ -0,0 +1,336 @@
+package split
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// splitDefinitionPromotionGetter is implemented by both *schema.ResourceData and *schema.ResourceDiff.
+type splitDefinitionPromotionGetter interface {
+	resourceGetter
+	GetOkExists(key string) (interface{}, bool)
+}
+
+func resourceSplitSplitDefinitionPromotion() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSplitSplitDefinitionPromotionCreate,
+		ReadContext:   resourceSplitSplitDefinitionPromotionRead,
+		UpdateContext: resourceSplitSplitDefinitionPromotionUpdate,
+		DeleteContext: resourceSplitSplitDefinitionPromotionDelete,
+
+		Timeouts: defaultResourceTimeouts(true),
+
+		CustomizeDiff: resourceSplitSplitDefinitionPromotionCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSplitSplitDefinitionPromotionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"split_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"source_environment_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"target_environment_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
+			"drop_individual_targets": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"traffic_allocation": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(0, 100),
+			},
+
+			"title": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"target_definition_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceSplitSplitDefinitionPromotionCustomizeDiff plans an update when the target environment's definition no
+// longer matches the promoted source definition, whether the source changed or the target was edited.
+func resourceSplitSplitDefinitionPromotionCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if err := diffSplitDefinitionPromotion(ctx, diff, meta); err != nil {
+		return err
+	}
+
+	return validateSplitDefinitionChangeNotes(ctx, diff, meta)
+}
+
+func diffSplitDefinitionPromotion(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok || config == nil || d.Id() == "" {
+		return nil
+	}
+
+	for _, key := range []string{"workspace_id", "split_name", "source_environment_id", "drop_individual_targets", "traffic_allocation"} {
+		if !d.NewValueKnown(key) {
+			return d.SetNewComputed("target_definition_json")
+		}
+	}
+
+	workspaceID := d.Get("workspace_id").(string)
+	splitName := d.Get("split_name").(string)
+	sourceEnvironmentID := d.Get("source_environment_id").(string)
+
+	source, _, getErr := config.API.Splits.GetDefinition(ctx, workspaceID, splitName, sourceEnvironmentID)
+	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			// The source definition may be created in the same apply.
+			return d.SetNewComputed("target_definition_json")
+		}
+		return fmt.Errorf("unable to fetch the definition of split %s in source environment %s: %w",
+			splitName, sourceEnvironmentID, getErr)
+	}
+
+	promoted, flattenErr := flattenSplitDefinitionJSON(constructSplitDefinitionPromotion(d, source), true)
+	if flattenErr != nil {
+		return flattenErr
+	}
+
+	if promoted != d.Get("target_definition_json").(string) {
+		log.Printf("[DEBUG] Definition of split %s in environment %s differs from the source environment",
+			splitName, d.Get("target_environment_id"))
+		return d.SetNew("target_definition_json", promoted)
+	}
+
+	return nil
+}
+
+// constructSplitDefinitionPromotion returns the request writing the source definition, with the overrides
+// applied, to the target environment.
+func constructSplitDefinitionPromotion(d splitDefinitionPromotionGetter, source *api.SplitDefinition) *api.SplitDefinitionRequest {
+	opts := splitDefinitionRequestFromDefinition(source)
+
+	if d.Get("drop_individual_targets").(bool) {
+		for i := range opts.Treatments {
+			opts.Treatments[i].Keys, opts.Treatments[i].Segments = nil, nil
+		}
+	}
+
+	// GetOkExists is used to tell an explicit 0 apart from an unset value.
+	if v, ok := d.GetOkExists("traffic_allocation"); ok {
+		opts.TrafficAllocation = v.(int)
+	}
+
+	return opts
+}
+
+func resourceSplitSplitDefinitionPromotionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Config).API
+
+	importID, parseErr := parseCompositeID(d.Id(), 4)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	workspaceID := importID[0]
+	splitName := importID[1]
+	sourceEnvironmentID := importID[2]
+	targetEnvironmentID := importID[3]
+
+	sd, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, targetEnvironmentID)
+	if getErr != nil {
+		return nil, getErr
+	}
+
+	d.SetId(sd.GetID())
+	d.Set("workspace_id", workspaceID)
+	d.Set("split_name", sd.GetName())
+	d.Set("source_environment_id", sourceEnvironmentID)
+	d.Set("target_environment_id", targetEnvironmentID)
+	d.Set("drop_individual_targets", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSplitSplitDefinitionPromotionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	sd, diags := promoteSplitDefinition(ctx, d, meta.(*Config))
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(sd.GetID())
+
+	return resourceSplitSplitDefinitionPromotionRead(ctx, d, meta)
+}
+
+func resourceSplitSplitDefinitionPromotionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Changes to the title or comment alone do not require promoting the definition again.
+	if d.HasChangesExcept("title", "comment") {
+		if _, diags := promoteSplitDefinition(ctx, d, meta.(*Config)); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceSplitSplitDefinitionPromotionRead(ctx, d, meta)
+}
+
+// promoteSplitDefinition writes the definition of the source environment to the target environment, creating
+// the target definition if it does not exist yet.
+func promoteSplitDefinition(ctx context.Context, d *schema.ResourceData, config *Config) (*api.SplitDefinition, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	client := config.API
+
+	workspaceID := getWorkspaceID(d)
+	splitName := getSplitName(d)
+	sourceEnvironmentID := d.Get("source_environment_id").(string)
+	targetEnvironmentID := d.Get("target_environment_id").(string)
+
+	source, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, sourceEnvironmentID)
+	if getErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to fetch the definition of split %v in source environment %v", splitName, sourceEnvironmentID),
+			Detail:   getErr.Error(),
+		})
+		return nil, diags
+	}
+
+	opts := constructSplitDefinitionPromotion(d, source)
+	opts.Title, opts.Comment = splitDefinitionChangeTitleAndComment(d, config)
+
+	_, _, targetErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, targetEnvironmentID)
+	if targetErr != nil && !api.IsNotFound(targetErr) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to fetch the definition of split %v in target environment %v", splitName, targetEnvironmentID),
+			Detail:   targetErr.Error(),
+		})
+		return nil, diags
+	}
+
+	log.Printf("[DEBUG] Promoting definition of split [%v] from environment %v to %v", splitName,
+		sourceEnvironmentID, targetEnvironmentID)
+
+	var sd *api.SplitDefinition
+	var promoteErr error
+	if targetErr != nil {
+		sd, _, promoteErr = client.Splits.CreateDefinition(ctx, workspaceID, splitName, targetEnvironmentID, opts)
+	} else {
+		sd, _, promoteErr = client.Splits.UpdateDefinitionFull(ctx, workspaceID, splitName, targetEnvironmentID, opts)
+	}
+	if promoteErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to promote definition of split %v to environment %v", splitName, targetEnvironmentID),
+			Detail:   promoteErr.Error(),
+		})
+		return nil, diags
+	}
+
+	log.Printf("[DEBUG] Promoted definition of split [%v] to environment %v", splitName, targetEnvironmentID)
+
+	return sd, diags
+}
+
+func resourceSplitSplitDefinitionPromotionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*Config).API
+
+	workspaceID := getWorkspaceID(d)
+	splitName := getSplitName(d)
+	targetEnvironmentID := d.Get("target_environment_id").(string)
+
+	sd, _, getErr := client.Splits.GetDefinition(ctx, workspaceID, splitName, targetEnvironmentID)
+	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Promoted split definition %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to fetch promoted split definition %s", d.Id()),
+			Detail:   getErr.Error(),
+		})
+		return diags
+	}
+
+	targetDefinitionJSON, flattenErr := flattenSplitDefinitionJSON(splitDefinitionRequestFromDefinition(sd), true)
+	if flattenErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to encode promoted split definition %s", d.Id()),
+			Detail:   flattenErr.Error(),
+		})
+		return diags
+	}
+
+	d.Set("workspace_id", workspaceID)
+	d.Set("split_name", sd.GetName())
+	d.Set("target_environment_id", sd.GetEnvironment().GetID())
+	d.Set("target_definition_json", targetDefinitionJSON)
+
+	return diags
+}
+
+func resourceSplitSplitDefinitionPromotionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*Config).API
+
+	workspaceID := getWorkspaceID(d)
+	splitName := getSplitName(d)
+	targetEnvironmentID := d.Get("target_environment_id").(string)
+
+	log.Printf("[DEBUG] Deleting promoted split definition %s", d.Id())
+
+	_, deleteErr := client.Splits.RemoveDefinition(ctx, workspaceID, splitName, targetEnvironmentID)
+	if deleteErr != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to delete promoted split definition %s", d.Id()),
+			Detail:   deleteErr.Error(),
+		})
+		return diags
+	}
+
+	log.Printf("[DEBUG] Deleted promoted split definition %s", d.Id())
+
+	d.SetId("")
+
+	return diags
+}
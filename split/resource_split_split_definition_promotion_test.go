@@ -0,0 +1,187 @@
+package split
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSplitSplitDefinitionPromotion_Basic(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+	targetEnvName := fmt.Sprintf("tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplitDefinitionPromotion_basic(workspaceID, splitName, envID, trafficTypeID, targetEnvName, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition_promotion.foobar", "source_environment_id", envID),
+					resource.TestCheckResourceAttrPair(
+						"split_split_definition_promotion.foobar", "target_environment_id",
+						"split_environment.target", "id"),
+					resource.TestCheckResourceAttrSet(
+						"split_split_definition_promotion.foobar", "target_definition_json"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplitDefinitionPromotion_basic(workspaceID, splitName, envID, trafficTypeID, targetEnvName, 50),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition_promotion.foobar", "traffic_allocation", "50"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitSplitDefinitionPromotion_Lifecycle(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	workspaceID := fixtures.Workspace.GetID()
+	source := fixtures.Environment.GetID()
+	target := server.AddEnvironment(workspaceID, "Production", true).GetID()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+
+	ctx := context.Background()
+	if _, _, err := client.Splits.Create(ctx, workspaceID, fixtures.TrafficType.GetID(), &api.SplitCreateRequest{Name: "foobar"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	sourceDefinition, err := expandSplitDefinitionJSON(testSplitDefinitionJSON)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := client.Splits.CreateDefinition(ctx, workspaceID, "foobar", source, sourceDefinition); err != nil {
+		t.Fatalf("unable to create source definition: %s", err)
+	}
+
+	raw := map[string]interface{}{
+		"workspace_id":            workspaceID,
+		"split_name":              "foobar",
+		"source_environment_id":   source,
+		"target_environment_id":   target,
+		"drop_individual_targets": true,
+		"traffic_allocation":      0,
+	}
+
+	r := resourceSplitSplitDefinitionPromotion()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	promoted, _, err := client.Splits.GetDefinition(ctx, workspaceID, "foobar", target)
+	if err != nil {
+		t.Fatalf("unable to fetch target definition: %s", err)
+	}
+	if promoted.GetTrafficAllocation() != 0 || len(promoted.Rules) != 1 || promoted.Treatments[0].HasKeys() {
+		t.Fatalf("expected the overrides to be applied to the promoted definition, got %+v", promoted)
+	}
+
+	planned := func() *terraform.InstanceDiff {
+		diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return diff
+	}
+
+	if diff := planned(); diff != nil && len(diff.Attributes) > 0 {
+		t.Fatalf("expected no changes after promotion, got %+v", diff.Attributes)
+	}
+
+	// Changing the source definition is detected as drift of the target.
+	sourceDefinition.DefaultTreatment = "on"
+	if _, _, err := client.Splits.UpdateDefinitionFull(ctx, workspaceID, "foobar", source, sourceDefinition); err != nil {
+		t.Fatalf("unable to update source definition: %s", err)
+	}
+
+	diff := planned()
+	if diff == nil || diff.Attributes["target_definition_json"] == nil {
+		t.Fatalf("expected the changed source definition to be planned, got %+v", diff)
+	}
+
+	state, diags := r.Apply(ctx, d.State(), diff, config)
+	if diags.HasError() {
+		t.Fatalf("unable to apply the promotion: %+v", diags)
+	}
+	d = r.Data(state)
+
+	promoted, _, err = client.Splits.GetDefinition(ctx, workspaceID, "foobar", target)
+	if err != nil || promoted.GetDefaultTreatment() != "on" {
+		t.Fatalf("expected the changed source definition to be promoted, got %+v: %v", promoted, err)
+	}
+
+	if diff := planned(); diff != nil && len(diff.Attributes) > 0 {
+		t.Fatalf("expected no changes after promotion, got %+v", diff.Attributes)
+	}
+}
+
+func testAccCheckSplitSplitDefinitionPromotion_basic(workspaceID, splitName, envID, trafficTypeID, targetEnvName string, trafficAllocation int) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_environment" "target" {
+	workspace_id = "%[1]s"
+	name = "%[5]s"
+	production = true
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s"
+}
+
+resource "split_split_definition" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	environment_id = "%[3]s"
+
+	default_treatment = "off"
+	treatment {
+		name = "on"
+		configurations = "{}"
+	}
+	treatment {
+		name = "off"
+		configurations = "{}"
+	}
+
+	default_rule {
+		treatment = "off"
+		size = 100
+	}
+}
+
+resource "split_split_definition_promotion" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	source_environment_id = split_split_definition.foobar.environment_id
+	target_environment_id = split_environment.target.id
+	traffic_allocation = %[6]d
+}
+`, workspaceID, splitName, envID, trafficTypeID, targetEnvName, trafficAllocation)
+}
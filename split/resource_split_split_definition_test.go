@@ -1,11 +1,22 @@
 package split
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccSplitSplitDefinition_Basic(t *testing.T) {
@@ -341,3 +352,1211 @@ resource "split_split_definition" "foobar" {
 }
 `, workspaceID, trafficTypeName, splitName, splitDescription, envID, trafficTypeID)
 }
+
+func TestAccSplitSplitDefinition_AllMatchers(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplitDefinition_allMatchers(workspaceID, splitName, envID, trafficTypeID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.0.condition.0.matcher.0.type", "GREATER_THAN_OR_EQUAL_NUMBER"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.0.condition.0.matcher.0.number", "0"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.0.condition.0.matcher.0.negate", "true"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.0.condition.0.matcher.1.between.0.from", "10"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.0.condition.0.matcher.1.between.0.to", "20"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.0.condition.0.matcher.2.date", "1672531200000"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.0.condition.0.matcher.3.bool", "false"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.1.condition.0.matcher.0.depends.0.split_name", splitName+"-parent"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "rule.1.condition.0.matcher.0.depends.0.treatments.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitSplitDefinition_MatcherRoundTrip(t *testing.T) {
+	raw := map[string]interface{}{
+		"rule": []interface{}{
+			map[string]interface{}{
+				"bucket": []interface{}{
+					map[string]interface{}{"treatment": "on", "size": 100},
+				},
+				"condition": []interface{}{
+					map[string]interface{}{
+						"combiner": "AND",
+						"matcher": []interface{}{
+							map[string]interface{}{
+								"type":      "EQUAL_NUMBER",
+								"attribute": "age",
+								"number":    0,
+								"negate":    true,
+							},
+							map[string]interface{}{
+								"type":      "EQUAL_TO_BOOLEAN",
+								"attribute": "beta",
+								"bool":      false,
+							},
+							map[string]interface{}{
+								"type":      "BETWEEN_DATE",
+								"attribute": "signup",
+								"between": []interface{}{
+									map[string]interface{}{"from": 1000, "to": 2000},
+								},
+							},
+							map[string]interface{}{
+								"type": "IN_SPLIT",
+								"depends": []interface{}{
+									map[string]interface{}{"split_name": "parent", "treatments": []interface{}{"on"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, raw)
+	opts, err := constructSplitDefinitionRequestOpts(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	matchers := opts.Rules[0].GetCondition().Matchers
+	if matchers[0].Number == nil || matchers[0].GetNumber() != 0 || !matchers[0].GetNegate() {
+		t.Fatalf("expected a zero number and negation to be sent, got: %+v", matchers[0])
+	}
+	if matchers[1].Bool == nil || matchers[1].GetBool() {
+		t.Fatalf("expected a false bool to be sent, got: %+v", matchers[1])
+	}
+	if matchers[1].Number != nil || matchers[1].Date != nil {
+		t.Fatalf("expected number and date to be omitted, got: %+v", matchers[1])
+	}
+	if matchers[2].GetBetween().GetFrom() != 1000 || matchers[2].GetBetween().GetTo() != 2000 {
+		t.Fatalf("unexpected between: %+v", matchers[2].Between)
+	}
+	if matchers[3].GetDepends().GetSplitName() != "parent" || len(matchers[3].GetDepends().Treatments) != 1 {
+		t.Fatalf("unexpected depends: %+v", matchers[3].Depends)
+	}
+
+	rules := make([]*api.Rule, 0)
+	for i := range opts.Rules {
+		rules = append(rules, &opts.Rules[i])
+	}
+
+	read := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, map[string]interface{}{})
+	setRuleInState(read, &api.SplitDefinition{Rules: rules})
+
+	for _, key := range []string{
+		"rule.0.condition.0.matcher.0.negate",
+		"rule.0.condition.0.matcher.0.number",
+		"rule.0.condition.0.matcher.1.bool",
+		"rule.0.condition.0.matcher.2.between.0.from",
+		"rule.0.condition.0.matcher.2.between.0.to",
+		"rule.0.condition.0.matcher.3.depends.0.split_name",
+		"rule.0.condition.0.matcher.3.depends.0.treatments.#",
+	} {
+		if got, want := read.Get(key), d.Get(key); got != want {
+			t.Fatalf("%s: expected %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestAccSplitSplitDefinition_InvalidDefaultTreatment(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	trafficTypeName := fmt.Sprintf("tt-tftest-%s", acctest.RandString(10))
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	config := regexp.MustCompile(`default_treatment = "treatment_123"`).ReplaceAllString(
+		testAccCheckSplitSplitDefinition_basic(workspaceID, trafficTypeName, splitName, "my split description", envID, trafficTypeID),
+		`default_treatment = "treatment_999"`)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`default_treatment "treatment_999" is not one of the declared treatments`),
+			},
+		},
+	})
+}
+
+func TestAccSplitSplitDefinition_IndividualTargets(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplitDefinition_individualTargets(workspaceID, splitName, envID, trafficTypeID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "treatment.0.keys.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						"split_split_definition.foobar", "treatment.0.keys.*", "user_1"),
+					resource.TestCheckTypeSetElemAttr(
+						"split_split_definition.foobar", "treatment.0.keys.*", "user_2"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplitDefinition_individualTargets(workspaceID, splitName, envID, trafficTypeID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "manage_individual_targets", "false"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "treatment.0.keys.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitSplitDefinition_SetTreatmentInState(t *testing.T) {
+	on, off := "on", "off"
+	sd := &api.SplitDefinition{
+		Treatments: []*api.Treatment{
+			{Name: &on, Keys: []string{"user_2", "user_1"}, Segments: []string{"beta"}},
+			{Name: &off},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, map[string]interface{}{})
+	setTreatmentInState(d, sd)
+
+	if got := d.Get("treatment.0.keys").(*schema.Set); got.Len() != 2 || !got.Contains("user_1") || !got.Contains("user_2") {
+		t.Fatalf("expected keys to be read back, got: %v", got.List())
+	}
+	if got := d.Get("treatment.0.segments").(*schema.Set); got.Len() != 1 || !got.Contains("beta") {
+		t.Fatalf("expected segments to be read back, got: %v", got.List())
+	}
+
+	unmanaged := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, map[string]interface{}{
+		"manage_individual_targets": false,
+	})
+	setTreatmentInState(unmanaged, sd)
+
+	if got := unmanaged.Get("treatment.0.keys").(*schema.Set); got.Len() != 0 {
+		t.Fatalf("expected keys to be ignored, got: %v", got.List())
+	}
+
+	opts := &api.SplitDefinitionRequest{Treatments: []api.Treatment{{Name: &off}, {Name: &on}}}
+	preserveIndividualTargets(opts, sd)
+
+	if len(opts.Treatments[1].Keys) != 2 || len(opts.Treatments[1].Segments) != 1 || len(opts.Treatments[0].Keys) != 0 {
+		t.Fatalf("expected the current targets to be preserved, got: %+v", opts.Treatments)
+	}
+}
+
+func TestAccSplitSplitDefinition_Killed(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplitDefinition_killed(workspaceID, splitName, envID, trafficTypeID, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "killed", "true"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplitDefinition_killed(workspaceID, splitName, envID, trafficTypeID, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "killed", "false"),
+				),
+			},
+			{
+				// The kill state in the configuration is ignored.
+				Config: testAccCheckSplitSplitDefinition_killed(workspaceID, splitName, envID, trafficTypeID, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "killed", "false"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "ignore_kill_state", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSplitSplitDefinition_PartialUpdate(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplitDefinition_partialUpdate(workspaceID, splitName, envID, trafficTypeID, 100, "off"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "update_strategy", "partial"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "traffic_allocation", "100"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplitDefinition_partialUpdate(workspaceID, splitName, envID, trafficTypeID, 50, "on"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "traffic_allocation", "50"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "default_rule.0.treatment", "on"),
+				),
+			},
+		},
+	})
+}
+
+// testSplitDefinitionDiff adapts *schema.ResourceData to splitDefinitionDiff with every value known.
+type testSplitDefinitionDiff struct {
+	*schema.ResourceData
+}
+
+func (testSplitDefinitionDiff) NewValueKnown(string) bool {
+	return true
+}
+
+func TestValidateSplitDefinition(t *testing.T) {
+	validDefinition := func() map[string]interface{} {
+		return map[string]interface{}{
+			"default_treatment":  "off",
+			"traffic_allocation": 100,
+			"treatment": []interface{}{
+				map[string]interface{}{"name": "on", "configurations": "{}"},
+				map[string]interface{}{"name": "off", "configurations": "{}"},
+			},
+			"default_rule": []interface{}{
+				map[string]interface{}{"treatment": "off", "size": 100},
+			},
+			"rule": []interface{}{
+				map[string]interface{}{
+					"bucket": []interface{}{
+						map[string]interface{}{"treatment": "on", "size": 50},
+						map[string]interface{}{"treatment": "off", "size": 50},
+					},
+					"condition": []interface{}{
+						map[string]interface{}{
+							"combiner": "AND",
+							"matcher": []interface{}{
+								map[string]interface{}{"type": "IN_SEGMENT", "string": "beta"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	rule := func(raw map[string]interface{}) map[string]interface{} {
+		return raw["rule"].([]interface{})[0].(map[string]interface{})
+	}
+
+	matcher := func(raw map[string]interface{}) map[string]interface{} {
+		condition := rule(raw)["condition"].([]interface{})[0].(map[string]interface{})
+		return condition["matcher"].([]interface{})[0].(map[string]interface{})
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(raw map[string]interface{})
+		err    string
+	}{
+		{
+			name:   "valid",
+			mutate: func(raw map[string]interface{}) {},
+		},
+		{
+			name:   "unknown default treatment",
+			mutate: func(raw map[string]interface{}) { raw["default_treatment"] = "maybe" },
+			err:    `default_treatment "maybe" is not one of the declared treatments`,
+		},
+		{
+			name: "unknown default rule treatment",
+			mutate: func(raw map[string]interface{}) {
+				raw["default_rule"].([]interface{})[0].(map[string]interface{})["treatment"] = "maybe"
+			},
+			err: `default_rule.0.treatment "maybe" is not one of the declared treatments`,
+		},
+		{
+			name: "default rule sizes",
+			mutate: func(raw map[string]interface{}) {
+				raw["default_rule"].([]interface{})[0].(map[string]interface{})["size"] = 90
+			},
+			err: errDefaultRuleSizeSum,
+		},
+		{
+			name: "unknown bucket treatment",
+			mutate: func(raw map[string]interface{}) {
+				rule(raw)["bucket"].([]interface{})[1].(map[string]interface{})["treatment"] = "maybe"
+			},
+			err: `rule.0.bucket.1.treatment "maybe" is not one of the declared treatments`,
+		},
+		{
+			name: "bucket sizes",
+			mutate: func(raw map[string]interface{}) {
+				rule(raw)["bucket"].([]interface{})[1].(map[string]interface{})["size"] = 40
+			},
+			err: `the sum of all bucket sizes of rule.0 must equal 100, got 90`,
+		},
+		{
+			name:   "traffic allocation",
+			mutate: func(raw map[string]interface{}) { raw["traffic_allocation"] = 101 },
+			err:    `traffic_allocation must be between 0 and 100, got 101`,
+		},
+		{
+			name: "duplicate treatments",
+			mutate: func(raw map[string]interface{}) {
+				raw["treatment"] = append(raw["treatment"].([]interface{}),
+					map[string]interface{}{"name": "on", "configurations": "{}"})
+			},
+			err: `treatment names must be unique, "on" is declared more than once`,
+		},
+		{
+			name: "configurations and configurations object",
+			mutate: func(raw map[string]interface{}) {
+				raw["treatment"].([]interface{})[0].(map[string]interface{})["configurations_object"] =
+					map[string]interface{}{"color": "red"}
+			},
+			err: `treatment.0: only one of configurations or configurations_object can be set`,
+		},
+		{
+			name:   "matcher missing string",
+			mutate: func(raw map[string]interface{}) { delete(matcher(raw), "string") },
+			err:    `rule.0.condition.0.matcher.0: matcher type IN_SEGMENT requires string to be set`,
+		},
+		{
+			name: "matcher missing between",
+			mutate: func(raw map[string]interface{}) {
+				matcher(raw)["type"] = "BETWEEN_NUMBER"
+			},
+			err: `rule.0.condition.0.matcher.0: matcher type BETWEEN_NUMBER requires a between block`,
+		},
+		{
+			name: "matcher missing depends",
+			mutate: func(raw map[string]interface{}) {
+				matcher(raw)["type"] = "IN_SPLIT"
+			},
+			err: `rule.0.condition.0.matcher.0: matcher type IN_SPLIT requires a depends block`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := validDefinition()
+			tc.mutate(raw)
+
+			d := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, raw)
+			err := validateSplitDefinition(testSplitDefinitionDiff{d})
+
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !regexp.MustCompile(regexp.QuoteMeta(tc.err)).MatchString(err.Error()) {
+				t.Fatalf("expected error %q, got: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func testAccCheckSplitSplitDefinition_allMatchers(workspaceID, splitName, envID, trafficTypeID string) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_split" "parent" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s-parent"
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s"
+}
+
+resource "split_split_definition" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	environment_id = "%[3]s"
+
+	default_treatment = "off"
+	treatment {
+		name = "on"
+		configurations = "{}"
+	}
+	treatment {
+		name = "off"
+		configurations = "{}"
+	}
+
+	default_rule {
+		treatment = "off"
+		size = 100
+	}
+
+	rule {
+		bucket {
+			treatment = "on"
+			size = 100
+		}
+		condition {
+			combiner = "AND"
+			matcher {
+				type = "GREATER_THAN_OR_EQUAL_NUMBER"
+				attribute = "age"
+				number = 0
+				negate = true
+			}
+			matcher {
+				type = "BETWEEN_NUMBER"
+				attribute = "age"
+				between {
+					from = 10
+					to = 20
+				}
+			}
+			matcher {
+				type = "ON_OR_AFTER_DATE"
+				attribute = "signup"
+				date = 1672531200000
+			}
+			matcher {
+				type = "EQUAL_TO_BOOLEAN"
+				attribute = "beta"
+				bool = false
+			}
+		}
+	}
+
+	rule {
+		bucket {
+			treatment = "on"
+			size = 100
+		}
+		condition {
+			combiner = "AND"
+			matcher {
+				type = "IN_SPLIT"
+				depends {
+					split_name = split_split.parent.name
+					treatments = ["on"]
+				}
+			}
+		}
+	}
+}
+`, workspaceID, splitName, envID, trafficTypeID)
+}
+
+func testAccCheckSplitSplitDefinition_individualTargets(workspaceID, splitName, envID, trafficTypeID string, manageTargets bool) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s"
+}
+
+resource "split_split_definition" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	environment_id = "%[3]s"
+	manage_individual_targets = %[5]t
+
+	default_treatment = "off"
+	treatment {
+		name = "on"
+		configurations = "{}"
+		keys = ["user_2", "user_1"]
+	}
+	treatment {
+		name = "off"
+		configurations = "{}"
+	}
+
+	default_rule {
+		treatment = "off"
+		size = 100
+	}
+}
+`, workspaceID, splitName, envID, trafficTypeID, manageTargets)
+}
+
+func testAccCheckSplitSplitDefinition_killed(workspaceID, splitName, envID, trafficTypeID string, killed, ignoreKillState bool) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s"
+}
+
+resource "split_split_definition" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	environment_id = "%[3]s"
+	killed = %[5]t
+	ignore_kill_state = %[6]t
+
+	default_treatment = "off"
+	treatment {
+		name = "on"
+		configurations = "{}"
+	}
+	treatment {
+		name = "off"
+		configurations = "{}"
+	}
+
+	default_rule {
+		treatment = "off"
+		size = 100
+	}
+}
+`, workspaceID, splitName, envID, trafficTypeID, killed, ignoreKillState)
+}
+
+// testSplitDefinitionChange reports the given keys as changed.
+type testSplitDefinitionChange []string
+
+func (c testSplitDefinitionChange) HasChange(key string) bool {
+	return slices.Contains(c, key)
+}
+
+func TestSplitSplitDefinition_ConstructPatch(t *testing.T) {
+	on, off := "on", "off"
+	size := 100
+	opts := &api.SplitDefinitionRequest{
+		Treatments:        []api.Treatment{{Name: &on}, {Name: &off}},
+		DefaultRule:       []api.Bucket{{Treatment: &off, Size: &size}},
+		DefaultTreatment:  off,
+		TrafficAllocation: 0,
+	}
+
+	patch := constructSplitDefinitionPatch(testSplitDefinitionChange{"traffic_allocation", "rule"}, opts)
+	if len(patch) != 2 {
+		t.Fatalf("expected 2 operations, got %d: %+v", len(patch), patch)
+	}
+
+	if patch[0].Op != "replace" || patch[0].Path != "/rules" {
+		t.Fatalf("unexpected operation: %+v", patch[0])
+	}
+	if rules, ok := patch[0].Value.([]api.Rule); !ok || rules == nil {
+		t.Fatalf("expected removed rules to be sent as an empty list, got %#v", patch[0].Value)
+	}
+
+	if patch[1].Path != "/trafficAllocation" || patch[1].Value != 0 {
+		t.Fatalf("unexpected operation: %+v", patch[1])
+	}
+
+	if patch := constructSplitDefinitionPatch(testSplitDefinitionChange{}, opts); len(patch) != 0 {
+		t.Fatalf("expected no operations, got %+v", patch)
+	}
+}
+
+func testAccCheckSplitSplitDefinition_partialUpdate(workspaceID, splitName, envID, trafficTypeID string, trafficAllocation int, defaultRuleTreatment string) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s"
+}
+
+resource "split_split_definition" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	environment_id = "%[3]s"
+	update_strategy = "partial"
+	traffic_allocation = %[5]d
+
+	default_treatment = "off"
+	treatment {
+		name = "on"
+		configurations = "{}"
+	}
+	treatment {
+		name = "off"
+		configurations = "{}"
+	}
+
+	default_rule {
+		treatment = "%[6]s"
+		size = 100
+	}
+}
+`, workspaceID, splitName, envID, trafficTypeID, trafficAllocation, defaultRuleTreatment)
+}
+
+func TestAccSplitSplitDefinition_DefinitionJSON(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	envID := testAccConfig.GetEnvironmentIDorSkip(t)
+	trafficTypeID := testAccConfig.GetTrafficTypeIDorSkip(t)
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplitDefinition_definitionJSON(workspaceID, splitName, envID, trafficTypeID, "off"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "traffic_allocation", "100"),
+					resource.TestCheckResourceAttr(
+						"split_split_definition.foobar", "treatment.#", "0"),
+					resource.TestCheckResourceAttrSet(
+						"split_split_definition.foobar", "definition_json"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplitDefinition_definitionJSON(workspaceID, splitName, envID, trafficTypeID, "on"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"split_split_definition.foobar", "definition_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitSplitDefinition_DefinitionJSONConflicts(t *testing.T) {
+	cases := []struct {
+		name  string
+		raw   map[string]interface{}
+		valid bool
+	}{
+		{
+			name:  "document only",
+			raw:   map[string]interface{}{"definition_json": testSplitDefinitionJSON},
+			valid: true,
+		},
+		{
+			name: "document and blocks",
+			raw: map[string]interface{}{
+				"definition_json":   testSplitDefinitionJSON,
+				"default_treatment": "off",
+			},
+		},
+		{
+			name: "neither",
+			raw:  map[string]interface{}{},
+		},
+		{
+			name: "not a split definition",
+			raw:  map[string]interface{}{"definition_json": `{"treatments": "on"}`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"workspace_id":   "a7cbd6a0-4d26-11ed-bdc3-0242ac120002",
+				"split_name":     "foobar",
+				"environment_id": "b1b4a6a0-4d26-11ed-bdc3-0242ac120002",
+			}
+			for k, v := range tc.raw {
+				raw[k] = v
+			}
+
+			diags := resourceSplitSplitDefinition().Validate(terraform.NewResourceConfigRaw(raw))
+			if diags.HasError() == tc.valid {
+				t.Fatalf("expected valid=%t, got: %+v", tc.valid, diags)
+			}
+		})
+	}
+}
+
+// testSplitDefinitionJSON is a valid definition_json document.
+const testSplitDefinitionJSON = `{
+	"treatments": [
+		{"name": "on", "configurations": "{}", "keys": ["b", "a"]},
+		{"name": "off"}
+	],
+	"defaultTreatment": "off",
+	"defaultRule": [{"treatment": "off", "size": 100}],
+	"rules": [
+		{
+			"buckets": [{"treatment": "on", "size": 100}],
+			"condition": {"matchers": [{"type": "IN_SEGMENT", "string": "beta", "negate": false}]}
+		}
+	]
+}`
+
+func TestSplitSplitDefinition_NormalizeDefinitionJSON(t *testing.T) {
+	normalized, err := normalizeSplitDefinitionJSON(testSplitDefinitionJSON, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"defaultRule":[{"size":100,"treatment":"off"}],"defaultTreatment":"off",` +
+		`"rules":[{"buckets":[{"size":100,"treatment":"on"}],"condition":{"combiner":"AND","matchers":[{"string":"beta","type":"IN_SEGMENT"}]}}],` +
+		`"trafficAllocation":100,"treatments":[{"configurations":"{}","keys":["a","b"],"name":"on"},{"name":"off"}]}`
+	if normalized != expected {
+		t.Fatalf("unexpected document:\n%s\nexpected:\n%s", normalized, expected)
+	}
+
+	// The API response has computed fields and fills in defaults, but describes the same definition.
+	on, off, beta, in, and := "on", "off", "beta", "IN_SEGMENT", "AND"
+	id, empty, configurations := "123", "", "{}"
+	size, allocation, negate := 100, 100, false
+	sd := &api.SplitDefinition{
+		ID:               &id,
+		Name:             &id,
+		DefaultTreatment: &off,
+		Treatments: []*api.Treatment{
+			{Name: &on, Configurations: &configurations, Description: &empty, Keys: []string{"a", "b"}},
+			{Name: &off, Description: &empty},
+		},
+		DefaultRule: []*api.Bucket{{Treatment: &off, Size: &size}},
+		Rules: []*api.Rule{{
+			Buckets:   []*api.Bucket{{Treatment: &on, Size: &size}},
+			Condition: &api.Condition{Combiner: &and, Matchers: []*api.Matcher{{Type: &in, String: &beta, Negate: &negate}}},
+		}},
+		TrafficAllocation: &allocation,
+	}
+	fromAPI, err := flattenSplitDefinitionJSON(splitDefinitionRequestFromDefinition(sd), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !splitDefinitionJSONEquivalent(testSplitDefinitionJSON, fromAPI, true) {
+		t.Fatalf("expected the API response to be equivalent, got:\n%s", fromAPI)
+	}
+
+	// Individual targets are ignored when not managed.
+	sd.Treatments[0].Keys = []string{"c"}
+	fromAPI, _ = flattenSplitDefinitionJSON(splitDefinitionRequestFromDefinition(sd), true)
+	if splitDefinitionJSONEquivalent(testSplitDefinitionJSON, fromAPI, true) {
+		t.Fatal("expected different keys to be a difference")
+	}
+	if !splitDefinitionJSONEquivalent(testSplitDefinitionJSON, fromAPI, false) {
+		t.Fatal("expected keys to be ignored when individual targets are not managed")
+	}
+}
+
+func TestSplitSplitDefinition_ConstructRequestOptsFromJSON(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, map[string]interface{}{
+		"definition_json":           testSplitDefinitionJSON,
+		"manage_individual_targets": false,
+	})
+
+	opts, err := constructSplitDefinitionRequestOpts(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if opts.TrafficAllocation != 100 || opts.DefaultTreatment != "off" || len(opts.Rules) != 1 {
+		t.Fatalf("unexpected request: %+v", opts)
+	}
+	if opts.Treatments[0].Keys != nil {
+		t.Fatalf("expected unmanaged keys to be omitted, got %v", opts.Treatments[0].Keys)
+	}
+}
+
+func TestValidateSplitDefinition_DefinitionJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  string
+		err  string
+	}{
+		{
+			name: "valid",
+			doc:  testSplitDefinitionJSON,
+		},
+		{
+			name: "unknown default treatment",
+			doc:  `{"treatments": [{"name": "on"}], "defaultTreatment": "off", "defaultRule": [{"treatment": "on", "size": 100}]}`,
+			err:  `default_treatment "off" is not one of the declared treatments`,
+		},
+		{
+			name: "bucket sizes",
+			doc: `{"treatments": [{"name": "on"}], "defaultTreatment": "on", "defaultRule": [{"treatment": "on", "size": 100}],
+				"rules": [{"buckets": [{"treatment": "on", "size": 90}], "condition": {"matchers": [{"type": "IN_SEGMENT", "string": "beta"}]}}]}`,
+			err: `the sum of all bucket sizes of rule.0 must equal 100, got 90`,
+		},
+		{
+			name: "matcher missing strings",
+			doc: `{"treatments": [{"name": "on"}], "defaultTreatment": "on", "defaultRule": [{"treatment": "on", "size": 100}],
+				"rules": [{"buckets": [{"treatment": "on", "size": 100}], "condition": {"matchers": [{"type": "STARTS_WITH"}]}}]}`,
+			err: `rule.0.condition.0.matcher.0: matcher type STARTS_WITH requires strings to be set`,
+		},
+		{
+			name: "traffic allocation",
+			doc:  `{"treatments": [{"name": "on"}], "defaultTreatment": "on", "defaultRule": [{"treatment": "on", "size": 100}], "trafficAllocation": 101}`,
+			err:  `traffic_allocation must be between 0 and 100, got 101`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := expandSplitDefinitionJSON(tc.doc)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			err = validateSplitDefinition(newSplitDefinitionDocumentDiff(opts))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !regexp.MustCompile(regexp.QuoteMeta(tc.err)).MatchString(err.Error()) {
+				t.Fatalf("expected error %q, got: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func testAccCheckSplitSplitDefinition_definitionJSON(workspaceID, splitName, envID, trafficTypeID, defaultRuleTreatment string) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = "%[4]s"
+	name = "%[2]s"
+}
+
+resource "split_split_definition" "foobar" {
+	workspace_id = "%[1]s"
+	split_name = split_split.foobar.name
+	environment_id = "%[3]s"
+
+	definition_json = jsonencode({
+		treatments = [
+			{ name = "on", configurations = "{}" },
+			{ name = "off", configurations = "{}" },
+		]
+		defaultTreatment = "off"
+		defaultRule = [{ treatment = "%[5]s", size = 100 }]
+		rules = [{
+			buckets = [{ treatment = "on", size = 100 }]
+			condition = {
+				matchers = [{ type = "IN_LIST_STRING", attribute = "country", strings = ["ca"] }]
+			}
+		}]
+	})
+}
+`, workspaceID, splitName, envID, trafficTypeID, defaultRuleTreatment)
+}
+
+func TestSplitSplitDefinition_DefinitionJSONLifecycle(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+
+	ctx := context.Background()
+	if _, _, err := client.Splits.Create(ctx, fixtures.Workspace.GetID(), fixtures.TrafficType.GetID(),
+		&api.SplitCreateRequest{Name: "foobar"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, map[string]interface{}{
+		"workspace_id":    fixtures.Workspace.GetID(),
+		"split_name":      "foobar",
+		"environment_id":  fixtures.Environment.GetID(),
+		"definition_json": testSplitDefinitionJSON,
+	})
+
+	if diags := resourceSplitSplitDefinitionCreate(ctx, d, &Config{API: client}); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	if got := d.Get("definition_json").(string); got != testSplitDefinitionJSON {
+		t.Fatalf("expected the configured document to be kept, got:\n%s", got)
+	}
+	if n := len(d.Get("treatment").([]interface{})); n != 0 {
+		t.Fatalf("expected no treatment blocks in state, got %d", n)
+	}
+	if got := d.Get("traffic_allocation").(int); got != 100 {
+		t.Fatalf("expected the default traffic allocation, got %d", got)
+	}
+
+	sd, _, err := client.Splits.GetDefinition(ctx, fixtures.Workspace.GetID(), "foobar", fixtures.Environment.GetID())
+	if err != nil {
+		t.Fatalf("unable to fetch definition: %s", err)
+	}
+	if len(sd.Rules) != 1 || sd.GetDefaultTreatment() != "off" || len(sd.Treatments[0].Keys) != 2 {
+		t.Fatalf("unexpected definition: %+v", sd)
+	}
+}
+
+func TestSplitSplitDefinition_ConfigurationsSchemaOnlyChange(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	if _, _, err := client.Splits.Create(ctx, fixtures.Workspace.GetID(), fixtures.TrafficType.GetID(),
+		&api.SplitCreateRequest{Name: "foobar"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	raw := map[string]interface{}{
+		"workspace_id":          fixtures.Workspace.GetID(),
+		"split_name":            "foobar",
+		"environment_id":        fixtures.Environment.GetID(),
+		"definition_json":       testSplitDefinitionJSON,
+		"configurations_schema": `{"type": "object"}`,
+		"update_strategy":       "partial",
+	}
+
+	r := resourceSplitSplitDefinition()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	raw["configurations_schema"] = `{"type": "object", "properties": {"color": {"type": "string"}}}`
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	before := len(server.Requests())
+	if _, diags := r.Apply(ctx, d.State(), diff, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	// Only the schema used for local validation changed, so nothing is sent to update the definition.
+	for _, req := range server.Requests()[before:] {
+		if req.Method != "GET" {
+			t.Fatalf("expected no definition update, got %s %s", req.Method, req.Path)
+		}
+	}
+}
+
+func TestSplitSplitDefinition_DeleteWithChangeRequest(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	server.AutoResolveChangeRequests(api.ChangeRequestStatusApproved)
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	if _, _, err := client.Splits.Create(ctx, fixtures.Workspace.GetID(), fixtures.TrafficType.GetID(),
+		&api.SplitCreateRequest{Name: "foobar"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	r := resourceSplitSplitDefinition()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"workspace_id":    fixtures.Workspace.GetID(),
+		"split_name":      "foobar",
+		"environment_id":  fixtures.Environment.GetID(),
+		"definition_json": testSplitDefinitionJSON,
+		"change_request":  []interface{}{map[string]interface{}{"poll_interval": 1}},
+	})
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	since := len(server.Requests())
+	if diags := r.DeleteContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	// The definition is archived through a change request rather than removed directly.
+	for _, req := range server.Requests()[since:] {
+		if req.Method == http.MethodDelete {
+			t.Fatalf("expected no direct removal, got %s %s", req.Method, req.Path)
+		}
+		if req.Method == http.MethodPost && !strings.Contains(req.Body, `"operationType":"ARCHIVE"`) {
+			t.Fatalf("expected an archive change request, got %s", req.Body)
+		}
+	}
+
+	if _, _, err := client.Splits.GetDefinition(ctx, fixtures.Workspace.GetID(), "foobar", fixtures.Environment.GetID()); !api.IsNotFound(err) {
+		t.Fatalf("expected the definition to be removed, got: %v", err)
+	}
+}
+
+func TestSplitSplitDefinition_CreateWithScheduledChangeRequest(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	server.AutoResolveChangeRequests(api.ChangeRequestStatusApproved)
+	server.ScheduleChangeRequests(true)
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	if _, _, err := client.Splits.Create(ctx, fixtures.Workspace.GetID(), fixtures.TrafficType.GetID(),
+		&api.SplitCreateRequest{Name: "foobar"}); err != nil {
+		t.Fatalf("unable to create split: %s", err)
+	}
+
+	r := resourceSplitSplitDefinition()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"workspace_id":    fixtures.Workspace.GetID(),
+		"split_name":      "foobar",
+		"environment_id":  fixtures.Environment.GetID(),
+		"definition_json": testSplitDefinitionJSON,
+		"change_request":  []interface{}{map[string]interface{}{"poll_interval": 1}},
+	})
+
+	done := make(chan diag.Diagnostics)
+	go func() { done <- r.CreateContext(ctx, d, config) }()
+
+	// The change request is approved but only creates the definition once published.
+	var approved []*api.ChangeRequest
+	for i := 0; len(approved) == 0; i++ {
+		if i == 50 {
+			t.Fatal("timed out waiting for the change request to be approved")
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		approved, _, err = client.ChangeRequests.ListAll(ctx, &api.ChangeRequestListOpts{Status: api.ChangeRequestStatusApproved})
+		if err != nil {
+			t.Fatalf("unable to list change requests: %s", err)
+		}
+	}
+
+	if _, _, err := client.ChangeRequests.UpdateStatus(ctx, approved[0].GetID(),
+		&api.ChangeRequestStatusRequest{Status: api.ChangeRequestStatusPublished}); err != nil {
+		t.Fatalf("unable to publish change request: %s", err)
+	}
+
+	if diags := <-done; diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected the published definition to be in state")
+	}
+}
+
+func TestSplitSplitDefinition_RequiresTitleAndComments(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	strict := server.AddWorkspace("Strict")
+	requires := true
+	strict.RequiresTitleAndComments = &requires
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+
+	cases := []struct {
+		name       string
+		attributes map[string]interface{}
+		config     *Config
+		err        bool
+	}{
+		{
+			name:   "missing title and comment",
+			config: &Config{API: client},
+			err:    true,
+		},
+		{
+			name:       "missing title",
+			attributes: map[string]interface{}{"comment": "ships the new checkout"},
+			config:     &Config{API: client},
+		},
+		{
+			name:   "provider default comment",
+			config: &Config{API: client, DefaultChangeComment: "CI run 42"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"workspace_id":    strict.GetID(),
+				"split_name":      "foobar",
+				"environment_id":  fixtures.Environment.GetID(),
+				"definition_json": testSplitDefinitionJSON,
+			}
+			for k, v := range tc.attributes {
+				raw[k] = v
+			}
+
+			// The definition falls back to a default title, so only the comment must be configured.
+			_, err := resourceSplitSplitDefinition().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), tc.config)
+			if tc.err && (err == nil || !strings.Contains(err.Error(), "requires a comment")) {
+				t.Fatalf("expected a missing comment error, got: %v", err)
+			}
+			if !tc.err && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
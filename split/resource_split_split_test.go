@@ -1,9 +1,14 @@
 package split
 
 import (
+	"context"
 	"fmt"
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"regexp"
 	"testing"
 )
@@ -47,6 +52,76 @@ func TestAccSplitSplit_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSplitSplit_Tags(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	trafficTypeName := fmt.Sprintf("tt-tftest-%s", acctest.RandString(10))
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplit_tags(workspaceID, trafficTypeName, splitName, `"team-a", "cleanup-q3"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split.foobar", "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr(
+						"split_split.foobar", "tags.*", "team-a"),
+					resource.TestCheckTypeSetElemAttr(
+						"split_split.foobar", "tags.*", "cleanup-q3"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplit_tags(workspaceID, trafficTypeName, splitName, `"team-b"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split.foobar", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(
+						"split_split.foobar", "tags.*", "team-b"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplit_tags(workspaceID, trafficTypeName, splitName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split.foobar", "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSplitSplit_FlagSets(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	trafficTypeName := fmt.Sprintf("tt-tftest-%s", acctest.RandString(10))
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+	flagSetName := fmt.Sprintf("fs_tftest_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplit_flagSets(workspaceID, trafficTypeName, splitName, flagSetName, "split_flag_set.foobar.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split.foobar", "flag_sets.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"split_split.foobar", "flag_sets.*", "split_flag_set.foobar", "id"),
+				),
+			},
+			{
+				Config: testAccCheckSplitSplit_flagSets(workspaceID, trafficTypeName, splitName, flagSetName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_split.foobar", "flag_sets.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSplitSplit_InvalidName(t *testing.T) {
 	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
 	trafficTypeName := fmt.Sprintf("tt-tftest-%s", acctest.RandString(10))
@@ -63,6 +138,388 @@ func TestAccSplitSplit_InvalidName(t *testing.T) {
 	})
 }
 
+func TestAccSplitSplit_Disappears(t *testing.T) {
+	workspaceID := testAccConfig.GetWorkspaceIDorSkip(t)
+	trafficTypeName := fmt.Sprintf("tt-tftest-%s", acctest.RandString(10))
+	splitName := fmt.Sprintf("s-tftest-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitSplit_basic(workspaceID, trafficTypeName, splitName, "my split description"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSplitSplitDisappears("split_split.foobar"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestSplitSplit_RequiresTitleAndComments(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	strict := server.AddWorkspace("Strict")
+	requires := true
+	strict.RequiresTitleAndComments = &requires
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+
+	cases := []struct {
+		name        string
+		workspaceID string
+		attributes  map[string]interface{}
+		config      *Config
+		err         bool
+	}{
+		{
+			name:        "workspace does not require a title and comment",
+			workspaceID: fixtures.Workspace.GetID(),
+			config:      &Config{API: client},
+		},
+		{
+			name:        "missing title and comment",
+			workspaceID: strict.GetID(),
+			config:      &Config{API: client},
+			err:         true,
+		},
+		{
+			name:        "missing comment",
+			workspaceID: strict.GetID(),
+			attributes:  map[string]interface{}{"title": "release"},
+			config:      &Config{API: client},
+			err:         true,
+		},
+		{
+			name:        "resource title and comment",
+			workspaceID: strict.GetID(),
+			attributes:  map[string]interface{}{"title": "release", "comment": "ships the new checkout"},
+			config:      &Config{API: client},
+		},
+		{
+			name:        "provider defaults",
+			workspaceID: strict.GetID(),
+			config:      &Config{API: client, DefaultChangeTitle: "release", DefaultChangeComment: "CI run 42"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"workspace_id":    tc.workspaceID,
+				"traffic_type_id": fixtures.TrafficType.GetID(),
+				"name":            "my-split",
+			}
+			for k, v := range tc.attributes {
+				raw[k] = v
+			}
+
+			_, err := resourceSplitSplit().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), tc.config)
+			if tc.err && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.err && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSplitSplit_RequiresTitleAndCommentsOnUpdate(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	strict := server.AddWorkspace("Strict")
+	requires := true
+	strict.RequiresTitleAndComments = &requires
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	ctx := context.Background()
+	flagSetID := "c5b7e2a0-4d26-11ed-bdc3-0242ac120002"
+
+	r := resourceSplitSplit()
+	state := r.Data(nil)
+	state.SetId("my-split")
+	state.Set("workspace_id", strict.GetID())
+	state.Set("traffic_type_id", fixtures.TrafficType.GetID())
+	state.Set("name", "my-split")
+	state.Set("description", "checkout")
+
+	cases := []struct {
+		name       string
+		attributes map[string]interface{}
+		config     *Config
+		err        bool
+	}{
+		{
+			name:       "description and tags are sent without a title and comment",
+			attributes: map[string]interface{}{"description": "new checkout", "tags": []interface{}{"team-a"}},
+			config:     &Config{API: client},
+		},
+		{
+			name:       "flag sets require a comment",
+			attributes: map[string]interface{}{"flag_sets": []interface{}{flagSetID}},
+			config:     &Config{API: client},
+			err:        true,
+		},
+		{
+			name:       "flag sets are only sent with a comment",
+			attributes: map[string]interface{}{"flag_sets": []interface{}{flagSetID}},
+			config:     &Config{API: client, DefaultChangeComment: "CI run 42"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"workspace_id":    strict.GetID(),
+				"traffic_type_id": fixtures.TrafficType.GetID(),
+				"name":            "my-split",
+				"description":     "checkout",
+			}
+			for k, v := range tc.attributes {
+				raw[k] = v
+			}
+
+			_, err := r.Diff(ctx, state.State(), terraform.NewResourceConfigRaw(raw), tc.config)
+			if tc.err && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.err && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSplitSplit_Tags(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	workspaceID := fixtures.Workspace.GetID()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	raw := map[string]interface{}{
+		"workspace_id":    workspaceID,
+		"traffic_type_id": fixtures.TrafficType.GetID(),
+		"name":            "my-split",
+		"tags":            []interface{}{"team-a", "cleanup-q3"},
+	}
+
+	r := resourceSplitSplit()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	expectTags := func(want ...string) {
+		t.Helper()
+		sp, _, err := client.Splits.Get(ctx, workspaceID, "my-split")
+		if err != nil {
+			t.Fatalf("unable to fetch split: %s", err)
+		}
+		got := sortedStrings(sp.TagNames())
+		if fmt.Sprint(got) != fmt.Sprint(sortedStrings(want)) {
+			t.Fatalf("expected tags %v, got %v", want, got)
+		}
+	}
+
+	expectTags("team-a", "cleanup-q3")
+
+	// Tags changed outside of Terraform are detected on read and reverted on apply.
+	if _, err := client.Splits.UpdateTags(ctx, workspaceID, "my-split", []string{"team-b"}); err != nil {
+		t.Fatalf("unable to tag split: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.RequiresNew() || diff.Attributes["tags.#"] == nil {
+		t.Fatalf("expected an in-place update of the tags, got %+v", diff)
+	}
+
+	state, diags := r.Apply(ctx, d.State(), diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectTags("team-a", "cleanup-q3")
+
+	// An empty set removes every tag.
+	raw["tags"] = []interface{}{}
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil {
+		t.Fatal("expected the tags to be removed")
+	}
+	if state, diags = r.Apply(ctx, state, diff, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectTags()
+
+	// Tags added outside of Terraform are detected and removed when the attribute is not set.
+	if _, err := client.Splits.UpdateTags(ctx, workspaceID, "my-split", []string{"team-b"}); err != nil {
+		t.Fatalf("unable to tag split: %s", err)
+	}
+	d = r.Data(state)
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	delete(raw, "tags")
+	diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil {
+		t.Fatal("expected the tags added outside of Terraform to be removed")
+	}
+	if _, diags := r.Apply(ctx, d.State(), diff, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectTags()
+}
+
+func TestSplitSplit_FlagSets(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	fixtures := server.Seed()
+	workspaceID := fixtures.Workspace.GetID()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()), api.APIv3BaseURL(server.APIv3BaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	flagSetIDs := make([]string, 0)
+	for _, name := range []string{"backend", "frontend", "mobile"} {
+		f, _, err := client.FlagSets.Create(ctx, &api.FlagSetRequest{Name: &name, Workspace: &api.WorkspaceIDRef{ID: &workspaceID}})
+		if err != nil {
+			t.Fatalf("unable to create flag set: %s", err)
+		}
+		flagSetIDs = append(flagSetIDs, f.GetID())
+	}
+
+	raw := map[string]interface{}{
+		"workspace_id":    workspaceID,
+		"traffic_type_id": fixtures.TrafficType.GetID(),
+		"name":            "my-split",
+		"flag_sets":       []interface{}{flagSetIDs[0], flagSetIDs[1]},
+	}
+
+	r := resourceSplitSplit()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	expectFlagSets := func(want ...string) {
+		t.Helper()
+		sp, _, err := client.Splits.Get(ctx, workspaceID, "my-split")
+		if err != nil {
+			t.Fatalf("unable to fetch split: %s", err)
+		}
+		got := sortedStrings(sp.FlagSetIDs())
+		if fmt.Sprint(got) != fmt.Sprint(sortedStrings(want)) {
+			t.Fatalf("expected flag sets %v, got %v", want, got)
+		}
+	}
+
+	expectFlagSets(flagSetIDs[0], flagSetIDs[1])
+
+	// Membership changed outside of Terraform is detected on read and reverted on apply.
+	if _, _, err := client.Splits.RemoveFromFlagSets(ctx, workspaceID, "my-split", &api.SplitFlagSetsRequest{IDs: []string{flagSetIDs[0]}}); err != nil {
+		t.Fatalf("unable to remove split from flag set: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.RequiresNew() || diff.Attributes["flag_sets.#"] == nil {
+		t.Fatalf("expected an in-place update of the flag sets, got %+v", diff)
+	}
+
+	state, diags := r.Apply(ctx, d.State(), diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectFlagSets(flagSetIDs[0], flagSetIDs[1])
+
+	// Only the flag sets that changed are added or removed.
+	raw["flag_sets"] = []interface{}{flagSetIDs[1], flagSetIDs[2]}
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if state, diags = r.Apply(ctx, state, diff, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectFlagSets(flagSetIDs[1], flagSetIDs[2])
+
+	// Removing the attribute removes the split from every flag set.
+	delete(raw, "flag_sets")
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil {
+		t.Fatal("expected the split to be removed from its flag sets")
+	}
+	if _, diags := r.Apply(ctx, state, diff, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectFlagSets()
+}
+
+// testAccCheckSplitSplitDisappears deletes the split outside of Terraform so the next refresh
+// removes it from state.
+func testAccCheckSplitSplitDisappears(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		client, clientErr := testAccAPIClient()
+		if clientErr != nil {
+			return clientErr
+		}
+
+		_, deleteErr := client.Splits.Delete(context.Background(), rs.Primary.Attributes["workspace_id"], rs.Primary.Attributes["name"])
+		return deleteErr
+	}
+}
+
 func testAccCheckSplitSplit_basic(workspaceID, trafficTypeName, splitName, splitDescription string) string {
 	return fmt.Sprintf(`
 provider "split" {
@@ -82,3 +539,48 @@ resource "split_split" "foobar" {
 }
 `, workspaceID, trafficTypeName, splitName, splitDescription)
 }
+
+func testAccCheckSplitSplit_tags(workspaceID, trafficTypeName, splitName, tags string) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_traffic_type" "foobar" {
+	workspace_id = "%[1]s"
+	name = "%[2]s"
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = split_traffic_type.foobar.id
+	name = "%[3]s"
+	tags = [%[4]s]
+}
+`, workspaceID, trafficTypeName, splitName, tags)
+}
+
+func testAccCheckSplitSplit_flagSets(workspaceID, trafficTypeName, splitName, flagSetName, flagSets string) string {
+	return fmt.Sprintf(`
+provider "split" {
+	remove_environment_from_state_only = true
+}
+
+resource "split_traffic_type" "foobar" {
+	workspace_id = "%[1]s"
+	name = "%[2]s"
+}
+
+resource "split_flag_set" "foobar" {
+	workspace_id = "%[1]s"
+	name = "%[4]s"
+}
+
+resource "split_split" "foobar" {
+	workspace_id = "%[1]s"
+	traffic_type_id = split_traffic_type.foobar.id
+	name = "%[3]s"
+	flag_sets = [%[5]s]
+}
+`, workspaceID, trafficTypeName, splitName, flagSetName, flagSets)
+}
@@ -16,6 +16,8 @@ func resourceSplitTrafficType() *schema.Resource {
 		ReadContext:   resourceSplitTrafficTypeRead,
 		DeleteContext: resourceSplitTrafficTypeDelete,
 
+		Timeouts: defaultResourceTimeouts(false),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitTrafficTypeImport,
 		},
@@ -58,7 +60,7 @@ func resourceSplitTrafficTypeImport(ctx context.Context, d *schema.ResourceData,
 	workspaceID := importID[0]
 	trafficTypeID := importID[1]
 
-	tt, _, getErr := client.TrafficTypes.FindByID(workspaceID, trafficTypeID)
+	tt, _, getErr := client.TrafficTypes.FindByID(ctx, workspaceID, trafficTypeID)
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -85,7 +87,7 @@ func resourceSplitTrafficTypeCreate(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[DEBUG] Creating traffic type %v", opts.Name)
 
-	tt, _, createErr := client.TrafficTypes.Create(workspaceID, opts)
+	tt, _, createErr := client.TrafficTypes.Create(ctx, workspaceID, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -107,8 +109,14 @@ func resourceSplitTrafficTypeRead(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
 
-	tt, _, getErr := client.TrafficTypes.FindByID(getWorkspaceID(d), d.Id())
+	tt, _, getErr := client.TrafficTypes.FindByID(ctx, getWorkspaceID(d), d.Id())
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Traffic type %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch traffic type %s", d.Id()),
@@ -129,7 +137,7 @@ func resourceSplitTrafficTypeDelete(ctx context.Context, d *schema.ResourceData,
 	var diags diag.Diagnostics
 
 	log.Printf("[DEBUG] Deleting traffic type %s", d.Id())
-	_, deleteErr := client.TrafficTypes.Delete(d.Id())
+	_, deleteErr := client.TrafficTypes.Delete(ctx, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -17,6 +17,8 @@ func resourceSplitTrafficTypeAttribute() *schema.Resource {
 		UpdateContext: resourceSplitTrafficTypeAttributeUpdate,
 		DeleteContext: resourceSplitTrafficTypeAttributeDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitTrafficTypeAttributeImport,
 		},
@@ -100,7 +102,7 @@ func resourceSplitTrafficTypeAttributeImport(ctx context.Context, d *schema.Reso
 	trafficTypeID := importID[1]
 	attributeID := importID[2]
 
-	a, _, getErr := client.Attributes.FindByID(workspaceID, trafficTypeID, attributeID, &api.AttributeListQueryParams{MarkerLimit: 200})
+	a, _, getErr := client.Attributes.FindByID(ctx, workspaceID, trafficTypeID, attributeID, &api.AttributeListQueryParams{MarkerLimit: 200})
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -129,7 +131,7 @@ func resourceSplitTrafficTypeAttributeCreate(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] Creating traffic type attribute %v", *opts.Identifier)
 
-	a, _, createErr := client.Attributes.Create(workspaceID, trafficTypeID, opts)
+	a, _, createErr := client.Attributes.Create(ctx, workspaceID, trafficTypeID, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -154,8 +156,14 @@ func resourceSplitTrafficTypeAttributeRead(ctx context.Context, d *schema.Resour
 	workspaceID := getWorkspaceID(d)
 	trafficTypeID := getTrafficTypeID(d)
 
-	a, _, getErr := client.Attributes.FindByID(workspaceID, trafficTypeID, d.Id(), &api.AttributeListQueryParams{MarkerLimit: 200})
+	a, _, getErr := client.Attributes.FindByID(ctx, workspaceID, trafficTypeID, d.Id(), &api.AttributeListQueryParams{MarkerLimit: 200})
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] Traffic type attribute %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch traffic type attribute %s", d.Id()),
@@ -240,7 +248,7 @@ func resourceSplitTrafficTypeAttributeUpdate(ctx context.Context, d *schema.Reso
 
 	log.Printf("[DEBUG] Updating traffic type attribute %v", d.Id())
 
-	_, _, updateErr := client.Attributes.Update(workspaceID, trafficTypeID, d.Id(), opts)
+	_, _, updateErr := client.Attributes.Update(ctx, workspaceID, trafficTypeID, d.Id(), opts)
 	if updateErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -262,7 +270,7 @@ func resourceSplitTrafficTypeAttributeDelete(ctx context.Context, d *schema.Reso
 	trafficTypeID := getTrafficTypeID(d)
 
 	log.Printf("[DEBUG] Deleting attribute %s", d.Id())
-	_, deleteErr := client.Attributes.Delete(workspaceID, trafficTypeID, d.Id())
+	_, deleteErr := client.Attributes.Delete(ctx, workspaceID, trafficTypeID, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -17,6 +17,8 @@ func resourceSplitUser() *schema.Resource {
 		UpdateContext: resourceSplitUserUpdate,
 		DeleteContext: resourceSplitUserDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -41,6 +43,12 @@ func resourceSplitUser() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"group_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+			},
 		},
 	}
 }
@@ -55,9 +63,14 @@ func resourceSplitUserCreate(ctx context.Context, d *schema.ResourceData, meta i
 		log.Printf("[DEBUG] new user email is : %v", opts.Email)
 	}
 
+	if v, ok := d.GetOk("group_ids"); ok {
+		opts.Groups = api.NewUserGroupRefs(setToStringSlice(v.(*schema.Set)))
+		log.Printf("[DEBUG] new user group_ids is : %v", opts.Groups)
+	}
+
 	log.Printf("[DEBUG] Inviting user %s", opts.Email)
 
-	u, _, inviteErr := client.Users.Invite(opts)
+	u, _, inviteErr := client.Users.Invite(ctx, opts)
 	if inviteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -78,8 +91,14 @@ func resourceSplitUserRead(ctx context.Context, d *schema.ResourceData, meta int
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
 
-	u, _, getErr := client.Users.Get(d.Id())
+	u, _, getErr := client.Users.Get(ctx, d.Id())
 	if getErr != nil {
+		if api.IsNotFound(getErr) {
+			log.Printf("[WARN] User %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("Unable to fetch user %v", d.Id()),
@@ -92,6 +111,7 @@ func resourceSplitUserRead(ctx context.Context, d *schema.ResourceData, meta int
 	d.Set("name", u.GetName())
 	d.Set("2fa", u.GetTFA())
 	d.Set("status", u.GetStatus())
+	d.Set("group_ids", u.GroupIDs())
 
 	return diags
 }
@@ -99,21 +119,41 @@ func resourceSplitUserRead(ctx context.Context, d *schema.ResourceData, meta int
 func resourceSplitUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
-	opts := &api.UserUpdateRequest{}
 
-	if v, ok := d.GetOk("name"); ok {
-		opts.Name = v.(string)
-		log.Printf("[DEBUG] updated user name is : %v", opts.Name)
+	if ok := d.HasChange("email"); ok {
+		opts := &api.UserUpdateRequest{}
+
+		if v, ok := d.GetOk("name"); ok {
+			opts.Name = v.(string)
+			log.Printf("[DEBUG] updated user name is : %v", opts.Name)
+		}
+
+		_, _, updateErr := client.Users.Update(ctx, d.Id(), opts)
+		if updateErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to update user %v", opts.Email),
+				Detail:   updateErr.Error(),
+			})
+			return diags
+		}
 	}
 
-	_, _, updateErr := client.Users.Update(d.Id(), opts)
-	if updateErr != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Unable to update user %v", opts.Email),
-			Detail:   updateErr.Error(),
-		})
-		return diags
+	if ok := d.HasChange("group_ids"); ok {
+		groupIDs := setToStringSlice(d.Get("group_ids").(*schema.Set))
+		log.Printf("[DEBUG] Updating user groups %s: %v", d.Id(), groupIDs)
+
+		_, _, updateErr := client.Users.UpdateUserGroups(ctx, d.Id(), groupIDs)
+		if updateErr != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to update groups of user %v", d.Id()),
+				Detail:   updateErr.Error(),
+			})
+			return diags
+		}
+
+		log.Printf("[DEBUG] Updated user groups %s", d.Id())
 	}
 
 	return resourceSplitUserRead(ctx, d, meta)
@@ -124,7 +164,7 @@ func resourceSplitUserDelete(ctx context.Context, d *schema.ResourceData, meta i
 	client := meta.(*Config).API
 
 	// Check the status of the user prior to deletion.
-	u, _, getErr := client.Users.Get(d.Id())
+	u, _, getErr := client.Users.Get(ctx, d.Id())
 	if getErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -138,7 +178,7 @@ func resourceSplitUserDelete(ctx context.Context, d *schema.ResourceData, meta i
 	if u.GetStatus() == api.UserStatusPending {
 		log.Printf("[DEBUG] Deleting invitation for user %s", d.Id())
 
-		_, deleteErr := client.Users.DeletePendingUser(d.Id())
+		_, deleteErr := client.Users.DeletePendingUser(ctx, d.Id())
 		if deleteErr != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -155,7 +195,7 @@ func resourceSplitUserDelete(ctx context.Context, d *schema.ResourceData, meta i
 	if u.GetStatus() == api.UserStatusActive {
 		log.Printf("[DEBUG] Disabling user %s", d.Id())
 
-		_, _, deleteErr := client.Users.Update(d.Id(), &api.UserUpdateRequest{Status: api.UserStatusDeactivated})
+		_, _, deleteErr := client.Users.Update(ctx, d.Id(), &api.UserUpdateRequest{Status: api.UserStatusDeactivated})
 		if deleteErr != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
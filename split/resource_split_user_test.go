@@ -1,12 +1,17 @@
 package split
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/davidji99/terraform-provider-split/helper/fakesplit"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccSplitUser_Basic(t *testing.T) {
@@ -38,6 +43,140 @@ func TestAccSplitUser_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSplitUser_GroupIDs(t *testing.T) {
+	// Skip test if using harness_token as this resource is deprecated with harness_token
+	skipIfUsingHarnessToken(t, "split_user")
+
+	email := testAccConfig.GetUserEmailorSkip(t)
+	emailSplit := strings.Split(email, "@")
+	emailFormatted := fmt.Sprintf("%s+%s@%s", emailSplit[0], acctest.RandString(8), emailSplit[1])
+	groupName := fmt.Sprintf("tftest-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSplitUser_groupIDs(emailFormatted, groupName, "split_group.foobar.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_user.foobar", "group_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"split_user.foobar", "group_ids.*", "split_group.foobar", "id"),
+				),
+			},
+			{
+				Config: testAccCheckSplitUser_groupIDs(emailFormatted, groupName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"split_user.foobar", "group_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitUser_GroupIDs(t *testing.T) {
+	server := fakesplit.New()
+	defer server.Close()
+
+	client, err := api.New(api.APIKey("fake-api-key"), api.APIBaseURL(server.APIBaseURL()))
+	if err != nil {
+		t.Fatalf("unable to construct client: %s", err)
+	}
+	config := &Config{API: client}
+	ctx := context.Background()
+
+	groupIDs := make([]string, 0)
+	for _, name := range []string{"admins", "developers"} {
+		g, _, err := client.Groups.Create(ctx, &api.GroupRequest{Name: name})
+		if err != nil {
+			t.Fatalf("unable to create group: %s", err)
+		}
+		groupIDs = append(groupIDs, g.GetID())
+	}
+
+	raw := map[string]interface{}{
+		"email":     "jane@example.com",
+		"group_ids": []interface{}{groupIDs[0]},
+	}
+
+	r := resourceSplitUser()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if diags := r.CreateContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+
+	expectGroups := func(want ...string) {
+		t.Helper()
+		u, _, err := client.Users.Get(ctx, d.Id())
+		if err != nil {
+			t.Fatalf("unable to fetch user: %s", err)
+		}
+		if got := sortedStrings(u.GroupIDs()); fmt.Sprint(got) != fmt.Sprint(sortedStrings(want)) {
+			t.Fatalf("expected groups %v, got %v", want, got)
+		}
+	}
+
+	// The groups are sent with the invitation.
+	expectGroups(groupIDs[0])
+
+	// Groups changed outside of Terraform are detected on read.
+	if _, _, err := client.Users.UpdateUserGroups(ctx, d.Id(), groupIDs); err != nil {
+		t.Fatalf("unable to update user groups: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	if d.Get("group_ids").(*schema.Set).Len() != 2 {
+		t.Fatalf("expected the group change to be read, got %v", d.Get("group_ids"))
+	}
+
+	// Groups are updated in place.
+	raw["group_ids"] = []interface{}{groupIDs[1]}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.RequiresNew() {
+		t.Fatalf("expected an in-place update, got %+v", diff)
+	}
+	state, diags := r.Apply(ctx, d.State(), diff, config)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectGroups(groupIDs[1])
+
+	// Removing the attribute removes the user from every group.
+	delete(raw, "group_ids")
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil {
+		t.Fatal("expected the user to be removed from its groups")
+	}
+	if _, diags := r.Apply(ctx, state, diff, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	expectGroups()
+}
+
+func testAccCheckSplitUser_groupIDs(email, groupName, groupIDs string) string {
+	return fmt.Sprintf(`
+%s
+
+resource "split_group" "foobar" {
+	name = "%s"
+}
+
+resource "split_user" "foobar" {
+	email = "%s"
+	group_ids = [%s]
+}
+`, testAccGetProviderConfig(), groupName, email, groupIDs)
+}
+
 func testAccCheckSplitUser_basic(email string) string {
 	return fmt.Sprintf(`
 %s
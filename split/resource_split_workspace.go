@@ -17,6 +17,8 @@ func resourceSplitWorkspace() *schema.Resource {
 		UpdateContext: resourceSplitWorkspaceUpdate,
 		DeleteContext: resourceSplitWorkspaceDelete,
 
+		Timeouts: defaultResourceTimeouts(true),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSplitWorkspaceImport,
 		},
@@ -39,7 +41,7 @@ func resourceSplitWorkspace() *schema.Resource {
 func resourceSplitWorkspaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Config).API
 
-	w, _, getErr := client.Workspaces.FindByName(d.Id())
+	w, _, getErr := client.Workspaces.FindByName(ctx, d.Id())
 	if getErr != nil {
 		return nil, getErr
 	}
@@ -68,7 +70,7 @@ func resourceSplitWorkspaceCreate(ctx context.Context, d *schema.ResourceData, m
 
 	log.Printf("[DEBUG] Creating new workspace %v", opts.Name)
 
-	w, _, createErr := client.Workspaces.Create(opts)
+	w, _, createErr := client.Workspaces.Create(ctx, opts)
 	if createErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -106,7 +108,7 @@ func resourceSplitWorkspaceUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	log.Printf("[DEBUG] Updating workspace %v", d.Id())
 
-	_, _, updateErr := client.Workspaces.Update(d.Id(), opts)
+	_, _, updateErr := client.Workspaces.Update(ctx, d.Id(), opts)
 	if updateErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -125,8 +127,14 @@ func resourceSplitWorkspaceRead(ctx context.Context, d *schema.ResourceData, met
 	var diags diag.Diagnostics
 	client := meta.(*Config).API
 
-	workspace, _, findErr := client.Workspaces.FindByName(d.Get("name").(string))
+	workspace, _, findErr := client.Workspaces.FindByName(ctx, d.Get("name").(string))
 	if findErr != nil {
+		if api.IsNotFound(findErr) {
+			log.Printf("[WARN] Workspace %s not found, removing from state", d.Id())
+			d.SetId("")
+			return diags
+		}
+
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("unable to fetch workspace %s", d.Id()),
@@ -150,7 +158,7 @@ func resourceSplitWorkspaceDelete(ctx context.Context, d *schema.ResourceData, m
 	// deleting the workspace itself.
 	log.Printf("[DEBUG] Finding traffic types prior to workspace %s deletion", d.Id())
 
-	trafficTypes, _, listErr := client.TrafficTypes.List(d.Id())
+	trafficTypes, _, listErr := client.TrafficTypes.List(ctx, d.Id())
 	if listErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -163,7 +171,7 @@ func resourceSplitWorkspaceDelete(ctx context.Context, d *schema.ResourceData, m
 	log.Printf("[DEBUG] Deleting all traffic types associated with workspace %s", d.Id())
 	for _, tt := range trafficTypes {
 		log.Printf("[DEBUG] Deleting traffic type %s associated with workspace %s", tt.GetID(), d.Id())
-		_, deleteErr := client.TrafficTypes.Delete(tt.GetID())
+		_, deleteErr := client.TrafficTypes.Delete(ctx, tt.GetID())
 		if deleteErr != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -176,7 +184,7 @@ func resourceSplitWorkspaceDelete(ctx context.Context, d *schema.ResourceData, m
 	log.Printf("[DEBUG] Deleted all traffic types associated with workspace %s", d.Id())
 
 	log.Printf("[DEBUG] Deleting workspace %s", d.Id())
-	_, deleteErr := client.Workspaces.Delete(d.Id())
+	_, deleteErr := client.Workspaces.Delete(ctx, d.Id())
 	if deleteErr != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
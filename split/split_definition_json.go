@@ -0,0 +1,287 @@
+package split
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultTrafficAllocation is the traffic allocation of split definitions that do not set one.
+const defaultTrafficAllocation = 100
+
+// splitDefinitionDocument is the JSON form of a split definition accepted by definition_json. It uses the same
+// field names as the API, so that definitions exported from Split can be used as is. Fields the API computes,
+// such as the ID or the environment, are ignored.
+type splitDefinitionDocument struct {
+	Treatments        []api.Treatment `json:"treatments"`
+	Rules             []api.Rule      `json:"rules"`
+	DefaultRule       []api.Bucket    `json:"defaultRule"`
+	DefaultTreatment  string          `json:"defaultTreatment"`
+	TrafficAllocation *int            `json:"trafficAllocation"`
+}
+
+// expandSplitDefinitionJSON decodes a definition_json document into a split definition request.
+func expandSplitDefinitionJSON(s string) (*api.SplitDefinitionRequest, error) {
+	var doc splitDefinitionDocument
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		return nil, fmt.Errorf("unable to decode split definition: %w", err)
+	}
+
+	opts := &api.SplitDefinitionRequest{
+		Treatments:        doc.Treatments,
+		Rules:             doc.Rules,
+		DefaultRule:       doc.DefaultRule,
+		DefaultTreatment:  doc.DefaultTreatment,
+		TrafficAllocation: defaultTrafficAllocation,
+	}
+	if doc.TrafficAllocation != nil {
+		opts.TrafficAllocation = *doc.TrafficAllocation
+	}
+
+	return opts, nil
+}
+
+// flattenSplitDefinitionJSON returns the normalised definition_json document of a split definition request.
+//
+// The document only contains the fields that can be configured. Keys are sorted, empty values and API defaults
+// are removed and individual targets are sets, so that equivalent definitions always have the same document.
+func flattenSplitDefinitionJSON(opts *api.SplitDefinitionRequest, manageTargets bool) (string, error) {
+	doc := splitDefinitionDocument{
+		DefaultTreatment:  opts.DefaultTreatment,
+		DefaultRule:       opts.DefaultRule,
+		TrafficAllocation: &opts.TrafficAllocation,
+	}
+
+	for _, t := range opts.Treatments {
+		if t.Configurations != nil {
+			configurations := normalizeConfigurations(*t.Configurations)
+			t.Configurations = &configurations
+		}
+
+		if manageTargets {
+			t.Keys = sortedStrings(t.Keys)
+			t.Segments = sortedStrings(t.Segments)
+		} else {
+			t.Keys, t.Segments = nil, nil
+		}
+		doc.Treatments = append(doc.Treatments, t)
+	}
+
+	for _, r := range opts.Rules {
+		if r.Condition != nil {
+			c := *r.Condition
+			if c.GetCombiner() == "" {
+				// AND is the only combiner supported by Split, and the one returned when none was sent.
+				combiner := "AND"
+				c.Combiner = &combiner
+			}
+
+			matchers := make([]*api.Matcher, 0)
+			for _, m := range c.Matchers {
+				m := *m
+				if !m.GetNegate() {
+					m.Negate = nil
+				}
+				matchers = append(matchers, &m)
+			}
+			c.Matchers = matchers
+			r.Condition = &c
+		}
+		doc.Rules = append(doc.Rules, r)
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+
+	// Round trip through a generic value to drop empty values and sort the keys of every object.
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return "", err
+	}
+
+	b, err = json.Marshal(pruneEmptyJSONValues(v))
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// normalizeSplitDefinitionJSON returns the normalised form of a definition_json document.
+func normalizeSplitDefinitionJSON(s string, manageTargets bool) (string, error) {
+	opts, err := expandSplitDefinitionJSON(s)
+	if err != nil {
+		return "", err
+	}
+
+	return flattenSplitDefinitionJSON(opts, manageTargets)
+}
+
+// splitDefinitionJSONEquivalent returns true if both definition_json documents describe the same split definition.
+func splitDefinitionJSONEquivalent(a, b string, manageTargets bool) bool {
+	normalizedA, errA := normalizeSplitDefinitionJSON(a, manageTargets)
+	normalizedB, errB := normalizeSplitDefinitionJSON(b, manageTargets)
+
+	return errA == nil && errB == nil && normalizedA == normalizedB
+}
+
+// suppressEquivalentSplitDefinitionJSON ignores differences between definition_json documents that describe the
+// same split definition, such as the order of fields.
+func suppressEquivalentSplitDefinitionJSON(_, old, new string, d *schema.ResourceData) bool {
+	return splitDefinitionJSONEquivalent(old, new, manageIndividualTargets(d))
+}
+
+// validateSplitDefinitionJSON checks that definition_json is a split definition document.
+func validateSplitDefinitionJSON(v interface{}, k string) ([]string, []error) {
+	if _, err := expandSplitDefinitionJSON(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// splitDefinitionRequestFromDefinition returns the request that would create the given split definition.
+func splitDefinitionRequestFromDefinition(sd *api.SplitDefinition) *api.SplitDefinitionRequest {
+	opts := &api.SplitDefinitionRequest{
+		DefaultTreatment:  sd.GetDefaultTreatment(),
+		TrafficAllocation: sd.GetTrafficAllocation(),
+	}
+
+	for _, t := range sd.Treatments {
+		opts.Treatments = append(opts.Treatments, *t)
+	}
+	for _, r := range sd.Rules {
+		opts.Rules = append(opts.Rules, *r)
+	}
+	for _, b := range sd.DefaultRule {
+		opts.DefaultRule = append(opts.DefaultRule, *b)
+	}
+
+	return opts
+}
+
+// splitDefinitionDocumentDiff adapts a split definition request to splitDefinitionDiff, so that definition_json
+// is validated against the same rules as the nested blocks. Values are laid out like the blocks in state.
+type splitDefinitionDocumentDiff map[string]interface{}
+
+func newSplitDefinitionDocumentDiff(opts *api.SplitDefinitionRequest) splitDefinitionDocumentDiff {
+	treatments := make([]interface{}, 0)
+	for _, t := range opts.Treatments {
+		treatments = append(treatments, map[string]interface{}{
+			"name":           t.GetName(),
+			"configurations": t.GetConfigurations(),
+		})
+	}
+
+	defaultRule := make([]interface{}, 0)
+	for _, b := range opts.DefaultRule {
+		defaultRule = append(defaultRule, map[string]interface{}{"treatment": b.GetTreatment(), "size": b.GetSize()})
+	}
+
+	rules := make([]interface{}, 0)
+	for _, r := range opts.Rules {
+		buckets := make([]interface{}, 0)
+		for _, b := range r.Buckets {
+			buckets = append(buckets, map[string]interface{}{"treatment": b.GetTreatment(), "size": b.GetSize()})
+		}
+
+		matchers := make([]interface{}, 0)
+		for _, m := range r.GetCondition().Matchers {
+			matcher := flattenMatcher(m)
+			strs := make([]interface{}, 0)
+			for _, s := range m.Strings {
+				strs = append(strs, s)
+			}
+			matcher["strings"] = strs
+			matchers = append(matchers, matcher)
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"bucket":    buckets,
+			"condition": []interface{}{map[string]interface{}{"matcher": matchers}},
+		})
+	}
+
+	return splitDefinitionDocumentDiff{
+		"default_treatment":  opts.DefaultTreatment,
+		"traffic_allocation": opts.TrafficAllocation,
+		"treatment":          treatments,
+		"default_rule":       defaultRule,
+		"rule":               rules,
+	}
+}
+
+// Get returns the value at the given state path, such as rule.0.bucket.1.size.
+func (d splitDefinitionDocumentDiff) Get(key string) interface{} {
+	var v interface{} = map[string]interface{}(d)
+	for _, part := range strings.Split(key, ".") {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			v = t[part]
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil
+			}
+			v = t[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// NewValueKnown always returns true, as documents are only validated once known.
+func (splitDefinitionDocumentDiff) NewValueKnown(string) bool {
+	return true
+}
+
+// pruneEmptyJSONValues removes nulls, empty strings, empty arrays and empty objects from a decoded JSON value.
+func pruneEmptyJSONValues(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		pruned := make(map[string]interface{})
+		for k, e := range t {
+			if e = pruneEmptyJSONValues(e); e != nil {
+				pruned[k] = e
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, 0)
+		for _, e := range t {
+			if e = pruneEmptyJSONValues(e); e != nil {
+				pruned = append(pruned, e)
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case string:
+		if t == "" {
+			return nil
+		}
+	}
+	return v
+}
+
+// sortedStrings returns a sorted copy of s.
+func sortedStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	return sorted
+}
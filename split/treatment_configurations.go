@@ -0,0 +1,149 @@
+package split
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// configurationsSchemaURL identifies the user supplied JSON Schema of treatment configurations.
+const configurationsSchemaURL = "configurations_schema.json"
+
+// normalizeConfigurations returns the canonical form of treatment configurations, so that whitespace, key order
+// and numeric formatting do not matter. Invalid JSON is returned as is.
+func normalizeConfigurations(v interface{}) string {
+	normalized, err := structure.NormalizeJsonString(v)
+	if err != nil {
+		return v.(string)
+	}
+	return normalized
+}
+
+// suppressEquivalentConfigurationsDiff ignores differences between treatment configurations that decode to the
+// same JSON value.
+func suppressEquivalentConfigurationsDiff(_, old, new string, _ *schema.ResourceData) bool {
+	normalizedOld, errOld := structure.NormalizeJsonString(old)
+	normalizedNew, errNew := structure.NormalizeJsonString(new)
+
+	return errOld == nil && errNew == nil && normalizedOld == normalizedNew
+}
+
+// expandConfigurationsObject encodes a configurations_object map as treatment configurations.
+//
+// Values that are valid JSON, such as numbers, booleans or the output of jsonencode(), are decoded, while any
+// other value is kept as a string.
+func expandConfigurationsObject(m map[string]interface{}) (string, error) {
+	object := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		s := v.(string)
+
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+			object[k] = s
+			continue
+		}
+		object[k] = decoded
+	}
+
+	b, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// flattenConfigurationsObject returns the configurations_object map of treatment configurations, or false if the
+// configurations are not a JSON object.
+func flattenConfigurationsObject(configurations string) (map[string]interface{}, bool) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(configurations), &object); err != nil || object == nil {
+		return nil, false
+	}
+
+	m := make(map[string]interface{}, len(object))
+	for k, v := range object {
+		if s, ok := v.(string); ok && !json.Valid([]byte(s)) {
+			m[k] = s
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		m[k] = string(b)
+	}
+	return m, true
+}
+
+// treatmentConfigurations returns the configurations of a treatment block, from configurations_object when set.
+func treatmentConfigurations(treatment map[string]interface{}) (string, error) {
+	if m, ok := treatment["configurations_object"].(map[string]interface{}); ok && len(m) > 0 {
+		return expandConfigurationsObject(m)
+	}
+
+	configurations, _ := treatment["configurations"].(string)
+	return configurations, nil
+}
+
+// compileConfigurationsSchema compiles the JSON Schema that treatment configurations are validated against.
+func compileConfigurationsSchema(s string) (*jsonschema.Schema, error) {
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(s))
+	if err != nil {
+		return nil, err
+	}
+
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(configurationsSchemaURL, doc); err != nil {
+		return nil, err
+	}
+	return c.Compile(configurationsSchemaURL)
+}
+
+// validateConfigurationsSchema checks that configurations_schema is a valid JSON Schema.
+func validateConfigurationsSchema(v interface{}, k string) ([]string, []error) {
+	if _, err := compileConfigurationsSchema(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q: invalid JSON Schema: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// validateTreatmentConfigurations validates the configurations of every treatment against configurationsSchema.
+// Treatments without configurations are not validated.
+func validateTreatmentConfigurations(d splitDefinitionDiff, configurationsSchema string) error {
+	sch, err := compileConfigurationsSchema(configurationsSchema)
+	if err != nil {
+		return fmt.Errorf("configurations_schema: invalid JSON Schema: %w", err)
+	}
+
+	var errs []error
+	for i, raw := range d.Get("treatment").([]interface{}) {
+		treatment, ok := raw.(map[string]interface{})
+		if !ok || !allValuesKnown(d, fmt.Sprintf("treatment.%d", i), "configurations", "configurations_object") {
+			continue
+		}
+
+		configurations, err := treatmentConfigurations(treatment)
+		if err != nil || configurations == "" {
+			continue
+		}
+
+		instance, err := jsonschema.UnmarshalJSON(strings.NewReader(configurations))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("treatment.%d.configurations: %w", i, err))
+			continue
+		}
+
+		if err := sch.Validate(instance); err != nil {
+			errs = append(errs, fmt.Errorf("treatment %q configurations do not match configurations_schema: %w",
+				treatment["name"], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
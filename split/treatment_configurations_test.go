@@ -0,0 +1,188 @@
+package split
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/davidji99/terraform-provider-split/api"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSuppressEquivalentConfigurationsDiff(t *testing.T) {
+	cases := []struct {
+		old, new string
+		suppress bool
+	}{
+		{`{"a":1,"b":"x"}`, `{ "b": "x", "a": 1 }`, true},
+		{`{"a":1}`, `{"a":1.0}`, true},
+		{`{"a":[1,2]}`, `{"a":[2,1]}`, false},
+		{`{"a":1}`, `{"a":2}`, false},
+		{`{"a":1}`, `not json`, false},
+	}
+
+	for _, tc := range cases {
+		if got := suppressEquivalentConfigurationsDiff("", tc.old, tc.new, nil); got != tc.suppress {
+			t.Errorf("%s -> %s: expected %t, got %t", tc.old, tc.new, tc.suppress, got)
+		}
+	}
+
+	if got := normalizeConfigurations(`{ "b": 1.50, "a": {"d": true, "c": null} }`); got != `{"a":{"c":null,"d":true},"b":1.5}` {
+		t.Fatalf("unexpected normalised configurations: %s", got)
+	}
+}
+
+func TestConfigurationsObjectRoundTrip(t *testing.T) {
+	m := map[string]interface{}{
+		"color":   "red",
+		"size":    "3",
+		"enabled": "true",
+		"quoted":  `"3"`,
+		"nested":  `{"b":[1,2],"a":"x"}`,
+	}
+
+	configurations, err := expandConfigurationsObject(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `{"color":"red","enabled":true,"nested":{"a":"x","b":[1,2]},"quoted":"3","size":3}`
+	if configurations != expected {
+		t.Fatalf("unexpected configurations:\n%s\nexpected:\n%s", configurations, expected)
+	}
+
+	flattened, ok := flattenConfigurationsObject(configurations)
+	if !ok {
+		t.Fatal("expected configurations to be an object")
+	}
+
+	again, err := expandConfigurationsObject(flattened)
+	if err != nil || again != configurations {
+		t.Fatalf("expected the configurations to round trip, got %s: %v", again, err)
+	}
+
+	if _, ok := flattenConfigurationsObject(`[1,2]`); ok {
+		t.Fatal("expected an array not to be flattened into an object")
+	}
+}
+
+func TestSplitSplitDefinition_ConfigurationsObject(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, map[string]interface{}{
+		"treatment": []interface{}{
+			map[string]interface{}{
+				"name":                  "on",
+				"configurations_object": map[string]interface{}{"color": "red", "size": "3"},
+			},
+			map[string]interface{}{
+				"name":           "off",
+				"configurations": `{ "color": "blue" }`,
+			},
+		},
+	})
+
+	opts, err := constructSplitDefinitionRequestOpts(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := opts.Treatments[0].GetConfigurations(); got != `{"color":"red","size":3}` {
+		t.Fatalf("unexpected configurations: %s", got)
+	}
+
+	on, off := "on", "off"
+	onConfigurations, offConfigurations := `{"size": 3, "color": "red"}`, `{"color":"blue"}`
+	setTreatmentInState(d, &api.SplitDefinition{Treatments: []*api.Treatment{
+		{Name: &on, Configurations: &onConfigurations},
+		{Name: &off, Configurations: &offConfigurations},
+	}})
+
+	if got := d.Get("treatment.0.configurations").(string); got != "" {
+		t.Fatalf("expected configurations to be read into configurations_object, got %s", got)
+	}
+	if got := d.Get("treatment.0.configurations_object.size").(string); got != "3" {
+		t.Fatalf("unexpected configurations_object: %v", d.Get("treatment.0.configurations_object"))
+	}
+	if got := d.Get("treatment.1.configurations").(string); got != `{"color":"blue"}` {
+		t.Fatalf("unexpected configurations: %s", got)
+	}
+}
+
+func TestValidateTreatmentConfigurations(t *testing.T) {
+	configurationsSchema := `{
+		"type": "object",
+		"properties": {"color": {"type": "string"}},
+		"required": ["color"]
+	}`
+
+	cases := []struct {
+		name      string
+		treatment map[string]interface{}
+		err       string
+	}{
+		{
+			name:      "valid configurations",
+			treatment: map[string]interface{}{"name": "on", "configurations": `{"color": "red"}`},
+		},
+		{
+			name:      "valid configurations object",
+			treatment: map[string]interface{}{"name": "on", "configurations_object": map[string]interface{}{"color": "red"}},
+		},
+		{
+			name:      "no configurations",
+			treatment: map[string]interface{}{"name": "on"},
+		},
+		{
+			name:      "missing property",
+			treatment: map[string]interface{}{"name": "on", "configurations": `{"size": 3}`},
+			err:       `treatment "on" configurations do not match configurations_schema`,
+		},
+		{
+			name:      "wrong type",
+			treatment: map[string]interface{}{"name": "on", "configurations_object": map[string]interface{}{"color": "3"}},
+			err:       `treatment "on" configurations do not match configurations_schema`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceSplitSplitDefinition().Schema, map[string]interface{}{
+				"treatment": []interface{}{tc.treatment},
+			})
+
+			err := validateTreatmentConfigurations(testSplitDefinitionDiff{d}, configurationsSchema)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !regexp.MustCompile(regexp.QuoteMeta(tc.err)).MatchString(err.Error()) {
+				t.Fatalf("expected error %q, got: %v", tc.err, err)
+			}
+		})
+	}
+
+	if _, errs := validateConfigurationsSchema(`{"type": "nope"}`, "configurations_schema"); len(errs) == 0 {
+		t.Fatal("expected an invalid JSON Schema to be rejected")
+	}
+}
+
+func TestSplitSplitDefinition_ConfigurationsSchemaDiff(t *testing.T) {
+	raw := map[string]interface{}{
+		"workspace_id":          "a7cbd6a0-4d26-11ed-bdc3-0242ac120002",
+		"split_name":            "foobar",
+		"environment_id":        "b1b4a6a0-4d26-11ed-bdc3-0242ac120002",
+		"configurations_schema": `{"type": "object", "required": ["color"]}`,
+		"definition_json": `{
+			"treatments": [{"name": "on", "configurations": "{\"color\": \"red\"}"}, {"name": "off", "configurations": "{}"}],
+			"defaultTreatment": "off",
+			"defaultRule": [{"treatment": "off", "size": 100}]
+		}`,
+	}
+
+	_, err := resourceSplitSplitDefinition().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	if err == nil || !regexp.MustCompile(`treatment "off" configurations do not match`).MatchString(err.Error()) {
+		t.Fatalf("expected the document's configurations to be validated, got: %v", err)
+	}
+}